@@ -0,0 +1,105 @@
+package algorithm
+
+import (
+	"context"
+	"custom-hpa/algorithm/algorithmpb"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type Handler func(request Request) (Response, error)
+
+// StubServer serves external algorithm protocol by handler, it is used by tests and for local development of algorithms.
+// Grpc protocol is served over plaintext connection.
+type StubServer struct {
+	Endpoint   string
+	handler    Handler
+	listener   net.Listener
+	server     *http.Server
+	grpcServer *grpc.Server
+	mutex      sync.Mutex
+	requests   []Request
+}
+
+// NewStubServer starts server listening on address, use 127.0.0.1:0 to listen on random local port
+func NewStubServer(protocol string, address string, handler Handler) (*StubServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	s := &StubServer{handler: handler, listener: listener}
+	switch strings.ToLower(protocol) {
+	case ProtocolHttp, "":
+		s.Endpoint = "http://" + listener.Addr().String()
+		s.server = &http.Server{Handler: http.HandlerFunc(s.serveHttp)}
+		go s.server.Serve(listener)
+	case ProtocolGrpc:
+		s.Endpoint = listener.Addr().String()
+		s.grpcServer = grpc.NewServer()
+		algorithmpb.RegisterAlgorithmServer(s.grpcServer, &stubAlgorithmServer{stub: s})
+		go s.grpcServer.Serve(listener)
+	default:
+		listener.Close()
+		return nil, errors.New(fmt.Sprintf("unsupported protocol of external algorithm: %s", protocol))
+	}
+	return s, nil
+}
+
+// Requests returns requests received by server in order of arrival
+func (s *StubServer) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *StubServer) Close() error {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+		return nil
+	}
+	return s.server.Close()
+}
+
+func (s *StubServer) handle(request Request) (Response, error) {
+	s.mutex.Lock()
+	s.requests = append(s.requests, request)
+	s.mutex.Unlock()
+	return s.handler(request)
+}
+
+func (s *StubServer) serveHttp(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request Request
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := s.handle(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+type stubAlgorithmServer struct {
+	stub *StubServer
+}
+
+func (s *stubAlgorithmServer) Evaluate(ctx context.Context, message *algorithmpb.EvaluateRequest) (*algorithmpb.EvaluateResponse, error) {
+	response, err := s.stub.handle(fromEvaluateRequest(message))
+	if err != nil {
+		return nil, err
+	}
+	return toEvaluateResponse(response), nil
+}
@@ -0,0 +1,71 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ProtocolHttp = "http"
+	ProtocolGrpc = "grpc"
+)
+
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// Request holds recent test results of metric from the oldest to the newest, values of exogenous regressors by their name
+// aligned with them and current replicas of target
+type Request struct {
+	Metric          string              `json:"metric"`
+	Time            time.Time           `json:"time"`
+	History         []Sample            `json:"history"`
+	ExogenousValues map[string][]Sample `json:"exogenousValues,omitempty"`
+	Replicas        int                 `json:"replicas"`
+	ScaleDownValue  float64             `json:"scaleDownValue"`
+	ScaleUpValue    float64             `json:"scaleUpValue"`
+	Parameters      map[string]string   `json:"parameters,omitempty"`
+}
+
+// Response holds forecast of metric value compared with scale bounds, desired replicas take precedence over forecast
+type Response struct {
+	Forecast        *float64 `json:"forecast,omitempty"`
+	DesiredReplicas *int     `json:"desiredReplicas,omitempty"`
+}
+
+type Client interface {
+	Evaluate(ctx context.Context, request Request) (Response, error)
+}
+
+// NewClient returns client of external algorithm. Http endpoint is URL receiving POST requests, grpc endpoint serves
+// Algorithm service of algorithmpb/algorithm.proto at host:port or URL with http scheme for plaintext and https scheme for TLS connection.
+func NewClient(protocol string, endpoint string) (Client, error) {
+	if len(endpoint) <= 0 {
+		return nil, errors.New("endpoint of external algorithm should not be empty")
+	}
+	switch strings.ToLower(protocol) {
+	case ProtocolHttp, "":
+		return newHttpClient(endpoint), nil
+	case ProtocolGrpc:
+		client, err := newGrpcClient(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	return nil, errors.New(fmt.Sprintf("unsupported protocol of external algorithm: %s", protocol))
+}
+
+func validateResponse(response Response) error {
+	if response.Forecast == nil && response.DesiredReplicas == nil {
+		return errors.New("external algorithm returned neither forecast nor desired replicas")
+	}
+	if response.DesiredReplicas != nil && *response.DesiredReplicas < 0 {
+		return errors.New(fmt.Sprintf("external algorithm returned negative desired replicas %d", *response.DesiredReplicas))
+	}
+	return nil
+}
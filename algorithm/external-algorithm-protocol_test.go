@@ -0,0 +1,108 @@
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientEvaluate(t *testing.T) {
+	for _, protocol := range []string{ProtocolHttp, ProtocolGrpc} {
+		t.Run(protocol, func(t *testing.T) {
+			server, err := NewStubServer(protocol, "127.0.0.1:0", func(request Request) (Response, error) {
+				forecast := request.History[len(request.History)-1].Value * 2
+				replicas := request.Replicas + 1
+				return Response{Forecast: &forecast, DesiredReplicas: &replicas}, nil
+			})
+			if err != nil {
+				t.Fatalf("cannot start stub server: %s", err.Error())
+			}
+			defer server.Close()
+			client, err := NewClient(protocol, server.Endpoint)
+			if err != nil {
+				t.Fatalf("cannot create client: %s", err.Error())
+			}
+			request := Request{Metric: "load", History: []Sample{{Value: 10}, {Value: 20}}, Replicas: 3}
+
+			response, err := client.Evaluate(context.Background(), request)
+
+			if err != nil {
+				t.Fatalf("Evaluate() failed: %s", err.Error())
+			}
+			if response.Forecast == nil || *response.Forecast != 40 || response.DesiredReplicas == nil || *response.DesiredReplicas != 4 {
+				t.Errorf("Evaluate() = %+v, expected forecast 40 and 4 desired replicas", response)
+			}
+			if requests := server.Requests(); len(requests) != 1 || requests[0].Metric != "load" || len(requests[0].History) != 2 {
+				t.Errorf("server received %+v", requests)
+			}
+		})
+	}
+}
+
+func TestClientEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler Handler
+		timeout time.Duration
+	}{
+		{
+			name: "handler error",
+			handler: func(request Request) (Response, error) {
+				return Response{}, errors.New("model is not trained")
+			},
+		},
+		{
+			name: "empty response",
+			handler: func(request Request) (Response, error) {
+				return Response{}, nil
+			},
+		},
+		{
+			name: "timeout",
+			handler: func(request Request) (Response, error) {
+				time.Sleep(500 * time.Millisecond)
+				forecast := 1.0
+				return Response{Forecast: &forecast}, nil
+			},
+			timeout: 50 * time.Millisecond,
+		},
+	}
+	for _, protocol := range []string{ProtocolHttp, ProtocolGrpc} {
+		for _, test := range tests {
+			t.Run(protocol+"/"+test.name, func(t *testing.T) {
+				server, err := NewStubServer(protocol, "127.0.0.1:0", test.handler)
+				if err != nil {
+					t.Fatalf("cannot start stub server: %s", err.Error())
+				}
+				defer server.Close()
+				client, _ := NewClient(protocol, server.Endpoint)
+				ctx := context.Background()
+				if test.timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, test.timeout)
+					defer cancel()
+				}
+				start := time.Now()
+
+				response, err := client.Evaluate(ctx, Request{Metric: "load"})
+
+				if err == nil {
+					t.Errorf("Evaluate() = %+v, expected error", response)
+				}
+				if test.timeout > 0 && time.Since(start) > 400*time.Millisecond {
+					t.Errorf("Evaluate() returned after %s, expected timeout after %s", time.Since(start), test.timeout)
+				}
+			})
+		}
+	}
+}
+
+func TestNewClientRejectsUnknownProtocol(t *testing.T) {
+	if _, err := NewClient("thrift", "localhost:9000"); err == nil {
+		t.Error("NewClient() accepted unknown protocol")
+	}
+	if _, err := NewClient(ProtocolHttp, ""); err == nil {
+		t.Error("NewClient() accepted empty endpoint")
+	}
+}
@@ -0,0 +1,136 @@
+package algorithm
+
+import (
+	"context"
+	"crypto/tls"
+	"custom-hpa/algorithm/algorithmpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"strings"
+	"time"
+)
+
+// grpcClient calls Evaluate method of Algorithm service defined in algorithmpb/algorithm.proto
+type grpcClient struct {
+	client algorithmpb.AlgorithmClient
+}
+
+// newGrpcClient does not wait for connection, it is established by the first call and re-established after failures
+func newGrpcClient(endpoint string) (*grpcClient, error) {
+	options := []grpc.DialOption{grpc.WithInsecure()}
+	if strings.HasPrefix(endpoint, "https://") {
+		options = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))}
+	}
+	endpoint = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://"), "/")
+	conn, err := grpc.Dial(endpoint, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcClient{client: algorithmpb.NewAlgorithmClient(conn)}, nil
+}
+
+func (c *grpcClient) Evaluate(ctx context.Context, request Request) (Response, error) {
+	message, err := c.client.Evaluate(ctx, toEvaluateRequest(request))
+	if err != nil {
+		return Response{}, err
+	}
+	response := fromEvaluateResponse(message)
+	return response, validateResponse(response)
+}
+
+func toEvaluateRequest(request Request) *algorithmpb.EvaluateRequest {
+	message := &algorithmpb.EvaluateRequest{
+		Metric:         request.Metric,
+		Time:           toTimestamp(request.Time),
+		History:        toSamples(request.History),
+		Replicas:       int32(request.Replicas),
+		ScaleDownValue: request.ScaleDownValue,
+		ScaleUpValue:   request.ScaleUpValue,
+		Parameters:     request.Parameters,
+	}
+	if len(request.ExogenousValues) > 0 {
+		message.ExogenousValues = map[string]*algorithmpb.Samples{}
+		for name, samples := range request.ExogenousValues {
+			message.ExogenousValues[name] = &algorithmpb.Samples{Samples: toSamples(samples)}
+		}
+	}
+	return message
+}
+
+func fromEvaluateRequest(message *algorithmpb.EvaluateRequest) Request {
+	request := Request{
+		Metric:         message.GetMetric(),
+		Time:           fromTimestamp(message.GetTime()),
+		History:        fromSamples(message.GetHistory()),
+		Replicas:       int(message.GetReplicas()),
+		ScaleDownValue: message.GetScaleDownValue(),
+		ScaleUpValue:   message.GetScaleUpValue(),
+		Parameters:     message.GetParameters(),
+	}
+	if len(message.GetExogenousValues()) > 0 {
+		request.ExogenousValues = map[string][]Sample{}
+		for name, samples := range message.GetExogenousValues() {
+			request.ExogenousValues[name] = fromSamples(samples.GetSamples())
+		}
+	}
+	return request
+}
+
+func toEvaluateResponse(response Response) *algorithmpb.EvaluateResponse {
+	message := &algorithmpb.EvaluateResponse{}
+	if response.Forecast != nil {
+		message.Forecast = &wrappers.DoubleValue{Value: *response.Forecast}
+	}
+	if response.DesiredReplicas != nil {
+		message.DesiredReplicas = &wrappers.Int32Value{Value: int32(*response.DesiredReplicas)}
+	}
+	return message
+}
+
+func fromEvaluateResponse(message *algorithmpb.EvaluateResponse) Response {
+	response := Response{}
+	if message.GetForecast() != nil {
+		forecast := message.GetForecast().GetValue()
+		response.Forecast = &forecast
+	}
+	if message.GetDesiredReplicas() != nil {
+		desiredReplicas := int(message.GetDesiredReplicas().GetValue())
+		response.DesiredReplicas = &desiredReplicas
+	}
+	return response
+}
+
+func toSamples(samples []Sample) []*algorithmpb.Sample {
+	messages := make([]*algorithmpb.Sample, 0, len(samples))
+	for _, sample := range samples {
+		messages = append(messages, &algorithmpb.Sample{Time: toTimestamp(sample.Time), Value: sample.Value})
+	}
+	return messages
+}
+
+func fromSamples(messages []*algorithmpb.Sample) []Sample {
+	samples := make([]Sample, 0, len(messages))
+	for _, message := range messages {
+		samples = append(samples, Sample{Time: fromTimestamp(message.GetTime()), Value: message.GetValue()})
+	}
+	return samples
+}
+
+func toTimestamp(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	value, _ := ptypes.TimestampProto(t)
+	return value
+}
+
+func fromTimestamp(value *timestamp.Timestamp) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	t, _ := ptypes.Timestamp(value)
+	return t
+}
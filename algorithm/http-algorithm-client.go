@@ -0,0 +1,53 @@
+package algorithm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type httpClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newHttpClient(endpoint string) *httpClient {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	return &httpClient{endpoint: endpoint, client: &http.Client{}}
+}
+
+func (c *httpClient) Evaluate(ctx context.Context, request Request) (Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return Response{}, err
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return Response{}, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := c.client.Do(httpRequest.WithContext(ctx))
+	if err != nil {
+		return Response{}, err
+	}
+	defer httpResponse.Body.Close()
+	data, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return Response{}, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return Response{}, errors.New(fmt.Sprintf("external algorithm responded with status %d: %s", httpResponse.StatusCode, strings.TrimSpace(string(data))))
+	}
+	var response Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Response{}, err
+	}
+	return response, validateResponse(response)
+}
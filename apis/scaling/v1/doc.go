@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package
+// +groupName=scaling.com
+
+// Package v1 is the v1 version of the scaling.com API, which is storage version of AutoscalingDefinition.
+package v1
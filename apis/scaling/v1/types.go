@@ -0,0 +1,194 @@
+package v1
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AutoscalingDefinition struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata"`
+	Spec               AutoscalingDefinitionSpec   `json:"spec"`
+	Status             AutoscalingDefinitionStatus `json:"status,omitempty"`
+}
+
+type AutoscalingDefinitionSpec struct {
+	ScaleTarget                AutoscalingDefinitionScaleTarget `json:"scaleTarget"`
+	MinReplicas                int                              `json:"minReplicas,omitempty"`
+	MaxReplicas                int                              `json:"maxReplicas,omitempty"`
+	IntervalBetweenAutoscaling string                           `json:"intervalBetweenAutoscaling,omitempty"`
+	ScalingStep                int                              `json:"scalingStep,omitempty"`
+	Mode                       string                           `json:"mode,omitempty"`
+	InitializationPeriod       string                           `json:"initializationPeriod,omitempty"`
+	ReadinessDelay             string                           `json:"readinessDelay,omitempty"`
+	Metrics                    []AutoscalingDefinitionMetric    `json:"metrics"`
+	Schedules                  []AutoscalingDefinitionSchedule  `json:"schedules,omitempty"`
+	ActivationMetric           AutoscalingDefinitionActivation  `json:"activationMetric,omitempty"`
+	Fallback                   AutoscalingDefinitionFallback    `json:"fallback,omitempty"`
+	Rollout                    AutoscalingDefinitionRollout     `json:"rollout,omitempty"`
+	DecisionHistoryLimit       int                              `json:"decisionHistoryLimit,omitempty"`
+}
+
+type AutoscalingDefinitionStatus struct {
+	State                  string                              `json:"state,omitempty"`
+	Message                string                              `json:"message,omitempty"`
+	Conditions             []AutoscalingDefinitionCondition    `json:"conditions,omitempty"`
+	RecommendedReplicas    int                                 `json:"recommendedReplicas,omitempty"`
+	LastRecommendationTime meta_v1.Time                        `json:"lastRecommendationTime,omitempty"`
+	LastScaleTime          meta_v1.Time                        `json:"lastScaleTime,omitempty"`
+	CooldownUntil          meta_v1.Time                        `json:"cooldownUntil,omitempty"`
+	LastDecision           AutoscalingDefinitionDecision       `json:"lastDecision,omitempty"`
+	Metrics                []AutoscalingDefinitionMetricStatus `json:"metrics,omitempty"`
+	Decisions              []AutoscalingDefinitionDecision     `json:"decisions,omitempty"`
+}
+
+type AutoscalingDefinitionDecision struct {
+	Time         meta_v1.Time                         `json:"time,omitempty"`
+	Metric       string                               `json:"metric,omitempty"`
+	FromReplicas int                                  `json:"fromReplicas,omitempty"`
+	ToReplicas   int                                  `json:"toReplicas,omitempty"`
+	Reason       string                               `json:"reason,omitempty"`
+	Policies     []string                             `json:"policies,omitempty"`
+	Inputs       []AutoscalingDefinitionDecisionInput `json:"inputs,omitempty"`
+}
+
+type AutoscalingDefinitionDecisionInput struct {
+	Metric                string                                        `json:"metric"`
+	ScrapeCount           int                                           `json:"scrapeCount,omitempty"`
+	Value                 string                                        `json:"value,omitempty"`
+	NumOfTests            int                                           `json:"numOfTests,omitempty"`
+	LowerBoundTestsPassed int                                           `json:"lowerBoundTestsPassed,omitempty"`
+	UpperBoundTestsPassed int                                           `json:"upperBoundTestsPassed,omitempty"`
+	PredictedValue        string                                        `json:"predictedValue,omitempty"`
+	ExogenousRegressors   []AutoscalingDefinitionDecisionExogenousInput `json:"exogenousRegressors,omitempty"`
+}
+
+type AutoscalingDefinitionDecisionExogenousInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type AutoscalingDefinitionMetricStatus struct {
+	Name                  string       `json:"name"`
+	Value                 string       `json:"value,omitempty"`
+	PredictedValue        string       `json:"predictedValue,omitempty"`
+	HistoryLength         int          `json:"historyLength,omitempty"`
+	RequiredHistoryLength int          `json:"requiredHistoryLength,omitempty"`
+	LastUpdateTime        meta_v1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type AutoscalingDefinitionCondition struct {
+	Type               string       `json:"type"`
+	Status             string       `json:"status"`
+	LastTransitionTime meta_v1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string       `json:"reason,omitempty"`
+	Message            string       `json:"message,omitempty"`
+}
+
+type AutoscalingDefinitionScaleTarget struct {
+	MatchNamespace string `json:"matchNamespace,omitempty"`
+	LabelName      string `json:"labelName"`
+	MatchLabel     string `json:"matchLabel"`
+	TargetType     string `json:"targetType,omitempty"`
+}
+
+type AutoscalingDefinitionMetric struct {
+	Name                                 string                                    `json:"name"`
+	MetricType                           string                                    `json:"metricType"`
+	PrometheusPath                       string                                    `json:"prometheusPath"`
+	PrometheusQuery                      string                                    `json:"prometheusQuery"`
+	ScaleDownValue                       string                                    `json:"scaleDownValue"`
+	ScaleUpValue                         string                                    `json:"scaleUpValue"`
+	ScaleValueType                       string                                    `json:"scaleValueType"`
+	ValueScope                           string                                    `json:"valueScope"`
+	NumOfTests                           int                                       `json:"numOfTests"`
+	Algorithm                            string                                    `json:"algorithm"`
+	TrimmedPercentage                    int                                       `json:"trimmedPercentage"`
+	Percentile                           int                                       `json:"percentile"`
+	EwmaHalfLife                         string                                    `json:"ewmaHalfLife"`
+	PercentageOfTestConditionFulfillment int                                       `json:"percentageOfTestConditionFulfillment"`
+	ScrapeInterval                       string                                    `json:"scrapeInterval"`
+	TestInterval                         string                                    `json:"testInterval"`
+	AutoregresionDegree                  int                                       `json:"autoregresionDegree"`
+	AutoregressionCoefficients           []string                                  `json:"autoregressionCoefficients"`
+	MovingAverageDegree                  int                                       `json:"movingAverageDegree"`
+	MovingAverageCoefficients            []string                                  `json:"movingAverageCoefficients"`
+	DifferencingOrder                    int                                       `json:"differencingOrder"`
+	SeasonalPeriod                       int                                       `json:"seasonalPeriod"`
+	SeasonalAutoregressionDegree         int                                       `json:"seasonalAutoregressionDegree"`
+	SeasonalAutoregressionCoefficients   []string                                  `json:"seasonalAutoregressionCoefficients"`
+	SeasonalMovingAverageDegree          int                                       `json:"seasonalMovingAverageDegree"`
+	SeasonalMovingAverageCoefficients    []string                                  `json:"seasonalMovingAverageCoefficients"`
+	SeasonalDifferencingOrder            int                                       `json:"seasonalDifferencingOrder"`
+	Intercept                            string                                    `json:"intercept"`
+	ExogenousRegressorQuery              string                                    `json:"exogenousRegressorQuery"`
+	ExogenousRegressorCoefficient        string                                    `json:"exogenousRegressorCoefficient"`
+	ExogenousRegressorMaxValue           string                                    `json:"exogenousRegressorMaxValue"`
+	ExogenousRegressors                  []AutoscalingDefinitionExogenousRegressor `json:"exogenousRegressors,omitempty"`
+	TargetValue                          string                                    `json:"targetValue"`
+	ProportionalGain                     string                                    `json:"proportionalGain"`
+	IntegralGain                         string                                    `json:"integralGain"`
+	DerivativeGain                       string                                    `json:"derivativeGain"`
+	MaxReplicaDelta                      int                                       `json:"maxReplicaDelta"`
+	BacklogQuery                         string                                    `json:"backlogQuery"`
+	ArrivalRateQuery                     string                                    `json:"arrivalRateQuery"`
+	ProcessingRateQuery                  string                                    `json:"processingRateQuery"`
+	TargetDrainTime                      string                                    `json:"targetDrainTime"`
+	PanicThreshold                       string                                    `json:"panicThreshold"`
+	PanicWindow                          int                                       `json:"panicWindow"`
+	PanicStabilizationPeriod             string                                    `json:"panicStabilizationPeriod"`
+	ExternalProtocol                     string                                    `json:"externalProtocol"`
+	ExternalEndpoint                     string                                    `json:"externalEndpoint"`
+	ExternalTimeout                      string                                    `json:"externalTimeout"`
+	ExternalHistoryLength                int                                       `json:"externalHistoryLength"`
+}
+
+type AutoscalingDefinitionExogenousRegressor struct {
+	Name           string `json:"name"`
+	Query          string `json:"query"`
+	Source         string `json:"source,omitempty"`
+	PrometheusPath string `json:"prometheusPath,omitempty"`
+	Coefficient    string `json:"coefficient,omitempty"`
+	MaxValue       string `json:"maxValue"`
+	Lag            int    `json:"lag,omitempty"`
+	Aggregation    string `json:"aggregation,omitempty"`
+}
+
+type AutoscalingDefinitionSchedule struct {
+	Name        string `json:"name"`
+	Cron        string `json:"cron"`
+	Timezone    string `json:"timezone,omitempty"`
+	Duration    string `json:"duration"`
+	MinReplicas int    `json:"minReplicas,omitempty"`
+	MaxReplicas int    `json:"maxReplicas,omitempty"`
+	Replicas    int    `json:"replicas,omitempty"`
+}
+
+type AutoscalingDefinitionActivation struct {
+	PrometheusPath      string `json:"prometheusPath,omitempty"`
+	PrometheusQuery     string `json:"prometheusQuery,omitempty"`
+	ActivationThreshold string `json:"activationThreshold,omitempty"`
+	ActivationReplicas  int    `json:"activationReplicas,omitempty"`
+	IdlePeriod          string `json:"idlePeriod,omitempty"`
+	ScrapeInterval      string `json:"scrapeInterval,omitempty"`
+}
+
+type AutoscalingDefinitionFallback struct {
+	Behavior         string `json:"behavior,omitempty"`
+	FailureThreshold int    `json:"failureThreshold,omitempty"`
+	Replicas         int    `json:"replicas,omitempty"`
+}
+
+type AutoscalingDefinitionRollout struct {
+	Policy           string `json:"policy,omitempty"`
+	PostRolloutDelay string `json:"postRolloutDelay,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AutoscalingDefinitionList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AutoscalingDefinition `json:"items"`
+}
@@ -0,0 +1,699 @@
+package v2
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"encoding/json"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnconvertibleValuesAnnotation keeps v1 values which v2 cannot represent, so that they survive conversion back to v1
+const UnconvertibleValuesAnnotation = "scaling.com/v1-unconvertible-values"
+
+// unconvertibleValue is original v1 value together with the v1 value converted from v2, original is restored only while v2 keeps it unchanged
+type unconvertibleValue struct {
+	Value     string `json:"value"`
+	Converted string `json:"converted"`
+}
+
+// ConvertFromV1 converts v1 definition to v2. Durations and numbers which cannot be parsed are kept in
+// UnconvertibleValuesAnnotation and restored by ConvertToV1. Scale bounds which cannot be parsed are kept as strings.
+func ConvertFromV1(in *scalingv1.AutoscalingDefinition) *AutoscalingDefinition {
+	out := &AutoscalingDefinition{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "AutoscalingDefinition"},
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+	}
+	removeAnnotation(&out.ObjectMeta, UnconvertibleValuesAnnotation)
+	spec := in.Spec
+	out.Spec = AutoscalingDefinitionSpec{
+		ScaleTarget: ScaleTarget{
+			MatchNamespace: spec.ScaleTarget.MatchNamespace,
+			LabelName:      spec.ScaleTarget.LabelName,
+			MatchLabel:     spec.ScaleTarget.MatchLabel,
+			TargetType:     TargetType(normalizeEnum(spec.ScaleTarget.TargetType, string(TargetTypeDeployment), string(TargetTypeReplicaSet))),
+		},
+		MinReplicas:                int32(spec.MinReplicas),
+		MaxReplicas:                int32(spec.MaxReplicas),
+		IntervalBetweenAutoscaling: parseDuration(spec.IntervalBetweenAutoscaling),
+		ScalingStep:                int32(spec.ScalingStep),
+		Mode:                       Mode(normalizeEnum(spec.Mode, string(ModeAuto), string(ModeRecommend))),
+		InitializationPeriod:       parseDuration(spec.InitializationPeriod),
+		ReadinessDelay:             parseDuration(spec.ReadinessDelay),
+		DecisionHistoryLimit:       int32(spec.DecisionHistoryLimit),
+	}
+	for _, metric := range spec.Metrics {
+		out.Spec.Metrics = append(out.Spec.Metrics, convertMetricFromV1(metric))
+	}
+	for _, schedule := range spec.Schedules {
+		duration := meta_v1.Duration{}
+		if d := parseDuration(schedule.Duration); d != nil {
+			duration = *d
+		}
+		out.Spec.Schedules = append(out.Spec.Schedules, Schedule{
+			Name:        schedule.Name,
+			Cron:        schedule.Cron,
+			Timezone:    schedule.Timezone,
+			Duration:    duration,
+			MinReplicas: int32(schedule.MinReplicas),
+			MaxReplicas: int32(schedule.MaxReplicas),
+			Replicas:    int32(schedule.Replicas),
+		})
+	}
+	if activation := spec.ActivationMetric; activation != (scalingv1.AutoscalingDefinitionActivation{}) {
+		out.Spec.ActivationMetric = &Activation{
+			PrometheusPath:      activation.PrometheusPath,
+			PrometheusQuery:     activation.PrometheusQuery,
+			ActivationThreshold: parseFloat(activation.ActivationThreshold),
+			ActivationReplicas:  int32(activation.ActivationReplicas),
+			IdlePeriod:          parseDuration(activation.IdlePeriod),
+			ScrapeInterval:      parseDuration(activation.ScrapeInterval),
+		}
+	}
+	if fallback := spec.Fallback; fallback != (scalingv1.AutoscalingDefinitionFallback{}) {
+		out.Spec.Fallback = &Fallback{
+			Behavior:         FallbackBehavior(normalizeEnum(fallback.Behavior, string(FallbackBehaviorHold), string(FallbackBehaviorSafeReplicas), string(FallbackBehaviorScaleUp))),
+			FailureThreshold: int32(fallback.FailureThreshold),
+			Replicas:         int32(fallback.Replicas),
+		}
+	}
+	if rollout := spec.Rollout; rollout != (scalingv1.AutoscalingDefinitionRollout{}) {
+		out.Spec.Rollout = &Rollout{
+			Policy:           RolloutPolicy(normalizeEnum(rollout.Policy, string(RolloutPolicyIgnore), string(RolloutPolicyDefer), string(RolloutPolicyCap))),
+			PostRolloutDelay: parseDuration(rollout.PostRolloutDelay),
+		}
+	}
+	out.Status = AutoscalingDefinitionStatus{
+		State:                  in.Status.State,
+		Message:                in.Status.Message,
+		RecommendedReplicas:    int32(in.Status.RecommendedReplicas),
+		LastRecommendationTime: in.Status.LastRecommendationTime,
+		LastScaleTime:          in.Status.LastScaleTime,
+		CooldownUntil:          in.Status.CooldownUntil,
+	}
+	for _, condition := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, Condition(condition))
+	}
+	if !in.Status.LastDecision.Time.IsZero() {
+		lastDecision := convertDecisionFromV1(in.Status.LastDecision)
+		out.Status.LastDecision = &lastDecision
+	}
+	for _, decision := range in.Status.Decisions {
+		out.Status.Decisions = append(out.Status.Decisions, convertDecisionFromV1(decision))
+	}
+	for _, metricStatus := range in.Status.Metrics {
+		out.Status.Metrics = append(out.Status.Metrics, MetricStatus{
+			Name:                  metricStatus.Name,
+			Value:                 parseFloat(metricStatus.Value),
+			PredictedValue:        parseFloat(metricStatus.PredictedValue),
+			HistoryLength:         int32(metricStatus.HistoryLength),
+			RequiredHistoryLength: int32(metricStatus.RequiredHistoryLength),
+			LastUpdateTime:        metricStatus.LastUpdateTime,
+		})
+	}
+	keepUnconvertibleValues(in, out)
+	return out
+}
+
+// ConvertToV1 converts v2 definition to v1
+func ConvertToV1(in *AutoscalingDefinition) *scalingv1.AutoscalingDefinition {
+	out := &scalingv1.AutoscalingDefinition{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: scalingv1.SchemeGroupVersion.String(), Kind: "AutoscalingDefinition"},
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+	}
+	spec := in.Spec
+	out.Spec = scalingv1.AutoscalingDefinitionSpec{
+		ScaleTarget: scalingv1.AutoscalingDefinitionScaleTarget{
+			MatchNamespace: spec.ScaleTarget.MatchNamespace,
+			LabelName:      spec.ScaleTarget.LabelName,
+			MatchLabel:     spec.ScaleTarget.MatchLabel,
+			TargetType:     string(spec.ScaleTarget.TargetType),
+		},
+		MinReplicas:                int(spec.MinReplicas),
+		MaxReplicas:                int(spec.MaxReplicas),
+		IntervalBetweenAutoscaling: formatDuration(spec.IntervalBetweenAutoscaling),
+		ScalingStep:                int(spec.ScalingStep),
+		Mode:                       string(spec.Mode),
+		InitializationPeriod:       formatDuration(spec.InitializationPeriod),
+		ReadinessDelay:             formatDuration(spec.ReadinessDelay),
+		DecisionHistoryLimit:       int(spec.DecisionHistoryLimit),
+	}
+	for _, metric := range spec.Metrics {
+		out.Spec.Metrics = append(out.Spec.Metrics, convertMetricToV1(metric))
+	}
+	for _, schedule := range spec.Schedules {
+		out.Spec.Schedules = append(out.Spec.Schedules, scalingv1.AutoscalingDefinitionSchedule{
+			Name:        schedule.Name,
+			Cron:        schedule.Cron,
+			Timezone:    schedule.Timezone,
+			Duration:    formatDuration(&schedule.Duration),
+			MinReplicas: int(schedule.MinReplicas),
+			MaxReplicas: int(schedule.MaxReplicas),
+			Replicas:    int(schedule.Replicas),
+		})
+	}
+	if activation := spec.ActivationMetric; activation != nil {
+		out.Spec.ActivationMetric = scalingv1.AutoscalingDefinitionActivation{
+			PrometheusPath:      activation.PrometheusPath,
+			PrometheusQuery:     activation.PrometheusQuery,
+			ActivationThreshold: formatFloat(activation.ActivationThreshold),
+			ActivationReplicas:  int(activation.ActivationReplicas),
+			IdlePeriod:          formatDuration(activation.IdlePeriod),
+			ScrapeInterval:      formatDuration(activation.ScrapeInterval),
+		}
+	}
+	if fallback := spec.Fallback; fallback != nil {
+		out.Spec.Fallback = scalingv1.AutoscalingDefinitionFallback{
+			Behavior:         string(fallback.Behavior),
+			FailureThreshold: int(fallback.FailureThreshold),
+			Replicas:         int(fallback.Replicas),
+		}
+	}
+	if rollout := spec.Rollout; rollout != nil {
+		out.Spec.Rollout = scalingv1.AutoscalingDefinitionRollout{
+			Policy:           string(rollout.Policy),
+			PostRolloutDelay: formatDuration(rollout.PostRolloutDelay),
+		}
+	}
+	out.Status = scalingv1.AutoscalingDefinitionStatus{
+		State:                  in.Status.State,
+		Message:                in.Status.Message,
+		RecommendedReplicas:    int(in.Status.RecommendedReplicas),
+		LastRecommendationTime: in.Status.LastRecommendationTime,
+		LastScaleTime:          in.Status.LastScaleTime,
+		CooldownUntil:          in.Status.CooldownUntil,
+	}
+	for _, condition := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, scalingv1.AutoscalingDefinitionCondition(condition))
+	}
+	if in.Status.LastDecision != nil {
+		out.Status.LastDecision = convertDecisionToV1(*in.Status.LastDecision)
+	}
+	for _, decision := range in.Status.Decisions {
+		out.Status.Decisions = append(out.Status.Decisions, convertDecisionToV1(decision))
+	}
+	for _, metricStatus := range in.Status.Metrics {
+		out.Status.Metrics = append(out.Status.Metrics, scalingv1.AutoscalingDefinitionMetricStatus{
+			Name:                  metricStatus.Name,
+			Value:                 formatFloat(metricStatus.Value),
+			PredictedValue:        formatFloat(metricStatus.PredictedValue),
+			HistoryLength:         int(metricStatus.HistoryLength),
+			RequiredHistoryLength: int(metricStatus.RequiredHistoryLength),
+			LastUpdateTime:        metricStatus.LastUpdateTime,
+		})
+	}
+	restoreUnconvertibleValues(out)
+	return out
+}
+
+func convertDecisionFromV1(in scalingv1.AutoscalingDefinitionDecision) Decision {
+	out := Decision{
+		Time:         in.Time,
+		Metric:       in.Metric,
+		FromReplicas: int32(in.FromReplicas),
+		ToReplicas:   int32(in.ToReplicas),
+		Reason:       in.Reason,
+		Policies:     in.Policies,
+	}
+	for _, input := range in.Inputs {
+		outInput := DecisionInput{
+			Metric:                input.Metric,
+			ScrapeCount:           int32(input.ScrapeCount),
+			Value:                 parseFloat(input.Value),
+			NumOfTests:            int32(input.NumOfTests),
+			LowerBoundTestsPassed: int32(input.LowerBoundTestsPassed),
+			UpperBoundTestsPassed: int32(input.UpperBoundTestsPassed),
+			PredictedValue:        parseFloat(input.PredictedValue),
+		}
+		for _, exogenousInput := range input.ExogenousRegressors {
+			outInput.ExogenousRegressors = append(outInput.ExogenousRegressors, DecisionExogenousInput{Name: exogenousInput.Name, Value: parseFloatValue(exogenousInput.Value)})
+		}
+		out.Inputs = append(out.Inputs, outInput)
+	}
+	return out
+}
+
+func convertDecisionToV1(in Decision) scalingv1.AutoscalingDefinitionDecision {
+	out := scalingv1.AutoscalingDefinitionDecision{
+		Time:         in.Time,
+		Metric:       in.Metric,
+		FromReplicas: int(in.FromReplicas),
+		ToReplicas:   int(in.ToReplicas),
+		Reason:       in.Reason,
+		Policies:     in.Policies,
+	}
+	for _, input := range in.Inputs {
+		outInput := scalingv1.AutoscalingDefinitionDecisionInput{
+			Metric:                input.Metric,
+			ScrapeCount:           int(input.ScrapeCount),
+			Value:                 formatFloat(input.Value),
+			NumOfTests:            int(input.NumOfTests),
+			LowerBoundTestsPassed: int(input.LowerBoundTestsPassed),
+			UpperBoundTestsPassed: int(input.UpperBoundTestsPassed),
+			PredictedValue:        formatFloat(input.PredictedValue),
+		}
+		for i := range input.ExogenousRegressors {
+			outInput.ExogenousRegressors = append(outInput.ExogenousRegressors, scalingv1.AutoscalingDefinitionDecisionExogenousInput{
+				Name:  input.ExogenousRegressors[i].Name,
+				Value: formatFloat(&input.ExogenousRegressors[i].Value),
+			})
+		}
+		out.Inputs = append(out.Inputs, outInput)
+	}
+	return out
+}
+
+func convertMetricFromV1(in scalingv1.AutoscalingDefinitionMetric) Metric {
+	out := Metric{
+		Name:                                 in.Name,
+		MetricType:                           MetricType(normalizeEnum(in.MetricType, string(MetricTypePrometheus))),
+		PrometheusPath:                       in.PrometheusPath,
+		PrometheusQuery:                      in.PrometheusQuery,
+		ScaleValueType:                       ScaleValueType(normalizeEnum(in.ScaleValueType, string(ScaleValueTypeInteger), string(ScaleValueTypeDouble), string(ScaleValueTypeBoolean), string(ScaleValueTypeTime), string(ScaleValueTypeString))),
+		ValueScope:                           ValueScope(normalizeEnum(in.ValueScope, string(ValueScopePerReplica), string(ValueScopeTotal))),
+		NumOfTests:                           int32(in.NumOfTests),
+		Algorithm:                            Algorithm(normalizeEnum(in.Algorithm, string(AlgorithmDefault), string(AlgorithmMean), string(AlgorithmMedian), string(AlgorithmTrimmedMean), string(AlgorithmPercentile), string(AlgorithmMax), string(AlgorithmMin), string(AlgorithmEwma), string(AlgorithmArimax), string(AlgorithmPid), string(AlgorithmQueue), string(AlgorithmExternal))),
+		PercentageOfTestConditionFulfillment: int32(in.PercentageOfTestConditionFulfillment),
+		ScrapeInterval:                       parseDuration(in.ScrapeInterval),
+		TestInterval:                         parseDuration(in.TestInterval),
+	}
+	out.ScaleDownValue, out.ScaleDownTime, out.ScaleDownString = parseScaleValue(in.ScaleDownValue, out.ScaleValueType)
+	out.ScaleUpValue, out.ScaleUpTime, out.ScaleUpString = parseScaleValue(in.ScaleUpValue, out.ScaleValueType)
+
+	if in.TrimmedPercentage != 0 {
+		out.TrimmedMean = &TrimmedMeanConfig{Percentage: int32(in.TrimmedPercentage)}
+	}
+	if in.Percentile != 0 {
+		out.Percentile = &PercentileConfig{Percentile: int32(in.Percentile)}
+	}
+	if len(in.EwmaHalfLife) > 0 {
+		out.Ewma = &EwmaConfig{HalfLife: parseDuration(in.EwmaHalfLife)}
+	}
+	if in.AutoregresionDegree != 0 || in.MovingAverageDegree != 0 || len(in.AutoregressionCoefficients) > 0 || len(in.MovingAverageCoefficients) > 0 ||
+		len(in.ExogenousRegressorQuery) > 0 || len(in.ExogenousRegressorCoefficient) > 0 || len(in.ExogenousRegressorMaxValue) > 0 || len(in.ExogenousRegressors) > 0 ||
+		in.DifferencingOrder != 0 || len(in.Intercept) > 0 || isSeasonalSet(in) {
+		out.Arimax = &ArimaxConfig{
+			AutoregressionDegree:       int32(in.AutoregresionDegree),
+			AutoregressionCoefficients: parseCoefficients(in.AutoregressionCoefficients),
+			MovingAverageDegree:        int32(in.MovingAverageDegree),
+			MovingAverageCoefficients:  parseCoefficients(in.MovingAverageCoefficients),
+			DifferencingOrder:          int32(in.DifferencingOrder),
+			Intercept:                  parseFloatValue(in.Intercept),
+		}
+		if isSeasonalSet(in) {
+			out.Arimax.Seasonal = &SeasonalConfig{
+				Period:                     int32(in.SeasonalPeriod),
+				AutoregressionDegree:       int32(in.SeasonalAutoregressionDegree),
+				AutoregressionCoefficients: parseCoefficients(in.SeasonalAutoregressionCoefficients),
+				MovingAverageDegree:        int32(in.SeasonalMovingAverageDegree),
+				MovingAverageCoefficients:  parseCoefficients(in.SeasonalMovingAverageCoefficients),
+				DifferencingOrder:          int32(in.SeasonalDifferencingOrder),
+			}
+		}
+		if len(in.ExogenousRegressorQuery) > 0 || len(in.ExogenousRegressorCoefficient) > 0 || len(in.ExogenousRegressorMaxValue) > 0 {
+			out.Arimax.ExogenousRegressor = &ExogenousRegressor{
+				Query:       in.ExogenousRegressorQuery,
+				Coefficient: parseFloatValue(in.ExogenousRegressorCoefficient),
+				MaxValue:    parseFloat(in.ExogenousRegressorMaxValue),
+			}
+		}
+		for _, regressor := range in.ExogenousRegressors {
+			out.Arimax.ExogenousRegressors = append(out.Arimax.ExogenousRegressors, ExogenousRegressor{
+				Name:           regressor.Name,
+				Query:          regressor.Query,
+				Source:         MetricType(normalizeEnum(regressor.Source, string(MetricTypePrometheus))),
+				PrometheusPath: regressor.PrometheusPath,
+				Coefficient:    parseFloatValue(regressor.Coefficient),
+				MaxValue:       parseFloat(regressor.MaxValue),
+				Lag:            int32(regressor.Lag),
+				Aggregation: ExogenousAggregation(normalizeEnum(regressor.Aggregation, string(ExogenousAggregationTrimmedMean), string(ExogenousAggregationMean),
+					string(ExogenousAggregationMedian), string(ExogenousAggregationMax), string(ExogenousAggregationMin), string(ExogenousAggregationLast))),
+			})
+		}
+	}
+	if len(in.TargetValue) > 0 || len(in.ProportionalGain) > 0 || len(in.IntegralGain) > 0 || len(in.DerivativeGain) > 0 || in.MaxReplicaDelta != 0 {
+		out.Pid = &PidConfig{
+			TargetValue:      parseFloatValue(in.TargetValue),
+			ProportionalGain: parseFloatValue(in.ProportionalGain),
+			IntegralGain:     parseFloatValue(in.IntegralGain),
+			DerivativeGain:   parseFloatValue(in.DerivativeGain),
+			MaxReplicaDelta:  int32(in.MaxReplicaDelta),
+		}
+	}
+	if len(in.BacklogQuery) > 0 || len(in.ArrivalRateQuery) > 0 || len(in.ProcessingRateQuery) > 0 || len(in.TargetDrainTime) > 0 {
+		out.Queue = &QueueConfig{
+			BacklogQuery:        in.BacklogQuery,
+			ArrivalRateQuery:    in.ArrivalRateQuery,
+			ProcessingRateQuery: in.ProcessingRateQuery,
+			TargetDrainTime:     parseDuration(in.TargetDrainTime),
+		}
+	}
+	if len(in.PanicThreshold) > 0 || in.PanicWindow != 0 || len(in.PanicStabilizationPeriod) > 0 {
+		out.Panic = &PanicConfig{
+			Threshold:           parseFloatValue(in.PanicThreshold),
+			Window:              int32(in.PanicWindow),
+			StabilizationPeriod: parseDuration(in.PanicStabilizationPeriod),
+		}
+	}
+	if len(in.ExternalProtocol) > 0 || len(in.ExternalEndpoint) > 0 || len(in.ExternalTimeout) > 0 || in.ExternalHistoryLength != 0 {
+		out.External = &ExternalConfig{
+			Protocol:      ExternalProtocol(normalizeEnum(in.ExternalProtocol, string(ExternalProtocolHttp), string(ExternalProtocolGrpc))),
+			Endpoint:      in.ExternalEndpoint,
+			Timeout:       parseDuration(in.ExternalTimeout),
+			HistoryLength: int32(in.ExternalHistoryLength),
+		}
+	}
+	return out
+}
+
+func convertMetricToV1(in Metric) scalingv1.AutoscalingDefinitionMetric {
+	out := scalingv1.AutoscalingDefinitionMetric{
+		Name:                                 in.Name,
+		MetricType:                           string(in.MetricType),
+		PrometheusPath:                       in.PrometheusPath,
+		PrometheusQuery:                      in.PrometheusQuery,
+		ScaleDownValue:                       formatScaleValue(in.ScaleDownValue, in.ScaleDownTime, in.ScaleDownString),
+		ScaleUpValue:                         formatScaleValue(in.ScaleUpValue, in.ScaleUpTime, in.ScaleUpString),
+		ScaleValueType:                       string(in.ScaleValueType),
+		ValueScope:                           string(in.ValueScope),
+		NumOfTests:                           int(in.NumOfTests),
+		Algorithm:                            string(in.Algorithm),
+		PercentageOfTestConditionFulfillment: int(in.PercentageOfTestConditionFulfillment),
+		ScrapeInterval:                       formatDuration(in.ScrapeInterval),
+		TestInterval:                         formatDuration(in.TestInterval),
+	}
+	if in.TrimmedMean != nil {
+		out.TrimmedPercentage = int(in.TrimmedMean.Percentage)
+	}
+	if in.Percentile != nil {
+		out.Percentile = int(in.Percentile.Percentile)
+	}
+	if in.Ewma != nil {
+		out.EwmaHalfLife = formatDuration(in.Ewma.HalfLife)
+	}
+	if in.Arimax != nil {
+		out.AutoregresionDegree = int(in.Arimax.AutoregressionDegree)
+		out.AutoregressionCoefficients = formatCoefficients(in.Arimax.AutoregressionCoefficients)
+		out.MovingAverageDegree = int(in.Arimax.MovingAverageDegree)
+		out.MovingAverageCoefficients = formatCoefficients(in.Arimax.MovingAverageCoefficients)
+		out.DifferencingOrder = int(in.Arimax.DifferencingOrder)
+		if in.Arimax.Intercept != 0 {
+			out.Intercept = formatFloat(&in.Arimax.Intercept)
+		}
+		if in.Arimax.Seasonal != nil {
+			out.SeasonalPeriod = int(in.Arimax.Seasonal.Period)
+			out.SeasonalAutoregressionDegree = int(in.Arimax.Seasonal.AutoregressionDegree)
+			out.SeasonalAutoregressionCoefficients = formatCoefficients(in.Arimax.Seasonal.AutoregressionCoefficients)
+			out.SeasonalMovingAverageDegree = int(in.Arimax.Seasonal.MovingAverageDegree)
+			out.SeasonalMovingAverageCoefficients = formatCoefficients(in.Arimax.Seasonal.MovingAverageCoefficients)
+			out.SeasonalDifferencingOrder = int(in.Arimax.Seasonal.DifferencingOrder)
+		}
+		if in.Arimax.ExogenousRegressor != nil {
+			out.ExogenousRegressorQuery = in.Arimax.ExogenousRegressor.Query
+			out.ExogenousRegressorCoefficient = formatFloat(&in.Arimax.ExogenousRegressor.Coefficient)
+			out.ExogenousRegressorMaxValue = formatFloat(in.Arimax.ExogenousRegressor.MaxValue)
+		}
+		for i, regressor := range in.Arimax.ExogenousRegressors {
+			out.ExogenousRegressors = append(out.ExogenousRegressors, scalingv1.AutoscalingDefinitionExogenousRegressor{
+				Name:           regressor.Name,
+				Query:          regressor.Query,
+				Source:         string(regressor.Source),
+				PrometheusPath: regressor.PrometheusPath,
+				Coefficient:    formatFloat(&in.Arimax.ExogenousRegressors[i].Coefficient),
+				MaxValue:       formatFloat(regressor.MaxValue),
+				Lag:            int(regressor.Lag),
+				Aggregation:    string(regressor.Aggregation),
+			})
+		}
+	}
+	if in.Pid != nil {
+		out.TargetValue = formatFloat(&in.Pid.TargetValue)
+		out.ProportionalGain = formatFloat(&in.Pid.ProportionalGain)
+		out.IntegralGain = formatFloat(&in.Pid.IntegralGain)
+		out.DerivativeGain = formatFloat(&in.Pid.DerivativeGain)
+		out.MaxReplicaDelta = int(in.Pid.MaxReplicaDelta)
+	}
+	if in.Queue != nil {
+		out.BacklogQuery = in.Queue.BacklogQuery
+		out.ArrivalRateQuery = in.Queue.ArrivalRateQuery
+		out.ProcessingRateQuery = in.Queue.ProcessingRateQuery
+		out.TargetDrainTime = formatDuration(in.Queue.TargetDrainTime)
+	}
+	if in.Panic != nil {
+		out.PanicThreshold = formatFloat(&in.Panic.Threshold)
+		out.PanicWindow = int(in.Panic.Window)
+		out.PanicStabilizationPeriod = formatDuration(in.Panic.StabilizationPeriod)
+	}
+	if in.External != nil {
+		out.ExternalProtocol = string(in.External.Protocol)
+		out.ExternalEndpoint = in.External.Endpoint
+		out.ExternalTimeout = formatDuration(in.External.Timeout)
+		out.ExternalHistoryLength = int(in.External.HistoryLength)
+	}
+	return out
+}
+
+func isSeasonalSet(in scalingv1.AutoscalingDefinitionMetric) bool {
+	return in.SeasonalPeriod != 0 || in.SeasonalAutoregressionDegree != 0 || in.SeasonalMovingAverageDegree != 0 || in.SeasonalDifferencingOrder != 0 ||
+		len(in.SeasonalAutoregressionCoefficients) > 0 || len(in.SeasonalMovingAverageCoefficients) > 0
+}
+
+func parseScaleValue(value string, scaleValueType ScaleValueType) (*float64, *meta_v1.Time, string) {
+	if len(value) <= 0 {
+		return nil, nil, ""
+	}
+	switch scaleValueType {
+	case ScaleValueTypeInteger, ScaleValueTypeDouble:
+		if number := parseFloat(value); number != nil {
+			return number, nil, ""
+		}
+	case ScaleValueTypeTime:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			metaTime := meta_v1.NewTime(t)
+			return nil, &metaTime, ""
+		}
+	}
+	return nil, nil, value
+}
+
+func formatScaleValue(number *float64, t *meta_v1.Time, value string) string {
+	if number != nil {
+		return formatFloat(number)
+	}
+	if t != nil {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return value
+}
+
+func parseDuration(value string) *meta_v1.Duration {
+	duration, err := time.ParseDuration(value)
+	if len(value) <= 0 || err != nil {
+		return nil
+	}
+	return &meta_v1.Duration{Duration: duration}
+}
+
+func formatDuration(duration *meta_v1.Duration) string {
+	if duration == nil {
+		return ""
+	}
+	return duration.Duration.String()
+}
+
+func parseFloat(value string) *float64 {
+	number, err := strconv.ParseFloat(value, 64)
+	if len(value) <= 0 || err != nil {
+		return nil
+	}
+	return &number
+}
+
+func parseFloatValue(value string) float64 {
+	if number := parseFloat(value); number != nil {
+		return *number
+	}
+	return 0
+}
+
+func formatFloat(number *float64) string {
+	if number == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*number, 'f', -1, 64)
+}
+
+func parseCoefficients(coefficients []string) []float64 {
+	var result []float64
+	for _, coef := range coefficients {
+		result = append(result, parseFloatValue(coef))
+	}
+	return result
+}
+
+func formatCoefficients(coefficients []float64) []string {
+	var result []string
+	for i := range coefficients {
+		result = append(result, formatFloat(&coefficients[i]))
+	}
+	return result
+}
+
+// normalizeEnum returns allowed value matching value case insensitively, unknown values are returned unchanged
+func normalizeEnum(value string, allowed ...string) string {
+	for _, a := range allowed {
+		if strings.EqualFold(a, value) {
+			return a
+		}
+	}
+	return value
+}
+
+// keepUnconvertibleValues stores v1 values, which are not kept by v2 even in other notation, in UnconvertibleValuesAnnotation
+func keepUnconvertibleValues(in *scalingv1.AutoscalingDefinition, out *AutoscalingDefinition) {
+	original, err := toFields(in)
+	if err != nil {
+		return
+	}
+	converted, err := toFields(ConvertToV1(out))
+	if err != nil {
+		return
+	}
+	values := map[string]unconvertibleValue{}
+	for _, field := range []string{"spec", "status"} {
+		collectUnconvertibleValues(field, original[field], converted[field], values)
+	}
+	if len(values) <= 0 {
+		return
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return
+	}
+	if out.Annotations == nil {
+		out.Annotations = map[string]string{}
+	}
+	out.Annotations[UnconvertibleValuesAnnotation] = string(raw)
+}
+
+// restoreUnconvertibleValues puts values from UnconvertibleValuesAnnotation back, unless they were changed through v2
+func restoreUnconvertibleValues(out *scalingv1.AutoscalingDefinition) {
+	raw, ok := out.Annotations[UnconvertibleValuesAnnotation]
+	if !ok {
+		return
+	}
+	removeAnnotation(&out.ObjectMeta, UnconvertibleValuesAnnotation)
+	values := map[string]unconvertibleValue{}
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return
+	}
+	fields, err := toFields(out)
+	if err != nil {
+		return
+	}
+	for path, value := range values {
+		restoreUnconvertibleValue(fields, strings.Split(path, "."), value)
+	}
+	restoredRaw, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	restored := scalingv1.AutoscalingDefinition{}
+	if err := json.Unmarshal(restoredRaw, &restored); err != nil {
+		return
+	}
+	*out = restored
+}
+
+func collectUnconvertibleValues(path string, original interface{}, converted interface{}, values map[string]unconvertibleValue) {
+	switch originalValue := original.(type) {
+	case map[string]interface{}:
+		convertedValue, _ := converted.(map[string]interface{})
+		for key, value := range originalValue {
+			collectUnconvertibleValues(path+"."+key, value, convertedValue[key], values)
+		}
+	case []interface{}:
+		convertedValue, _ := converted.([]interface{})
+		for i, value := range originalValue {
+			var convertedItem interface{}
+			if i < len(convertedValue) {
+				convertedItem = convertedValue[i]
+			}
+			collectUnconvertibleValues(path+"."+strconv.Itoa(i), value, convertedItem, values)
+		}
+	case string:
+		convertedValue, _ := converted.(string)
+		if !isEquivalentValue(originalValue, convertedValue) {
+			values[path] = unconvertibleValue{Value: originalValue, Converted: convertedValue}
+		}
+	}
+}
+
+func restoreUnconvertibleValue(node interface{}, path []string, value unconvertibleValue) {
+	if len(path) <= 0 {
+		return
+	}
+	switch nodeValue := node.(type) {
+	case map[string]interface{}:
+		if len(path) > 1 {
+			restoreUnconvertibleValue(nodeValue[path[0]], path[1:], value)
+			return
+		}
+		if current, _ := nodeValue[path[0]].(string); current == value.Converted {
+			nodeValue[path[0]] = value.Value
+		}
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(nodeValue) {
+			return
+		}
+		if len(path) > 1 {
+			restoreUnconvertibleValue(nodeValue[index], path[1:], value)
+			return
+		}
+		if current, _ := nodeValue[index].(string); current == value.Converted {
+			nodeValue[index] = value.Value
+		}
+	}
+}
+
+// isEquivalentValue reports whether converted value is the original one, possibly in other notation
+func isEquivalentValue(original string, converted string) bool {
+	if strings.EqualFold(original, converted) {
+		return true
+	}
+	if originalDuration, err := time.ParseDuration(original); err == nil {
+		convertedDuration, err := time.ParseDuration(converted)
+		return err == nil && originalDuration == convertedDuration
+	}
+	if originalNumber, err := strconv.ParseFloat(original, 64); err == nil {
+		convertedNumber, err := strconv.ParseFloat(converted, 64)
+		return err == nil && originalNumber == convertedNumber
+	}
+	if originalTime, err := time.Parse(time.RFC3339, original); err == nil {
+		convertedTime, err := time.Parse(time.RFC3339, converted)
+		return err == nil && originalTime.Equal(convertedTime)
+	}
+	return false
+}
+
+func toFields(object interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(raw, &fields)
+	return fields, err
+}
+
+func removeAnnotation(objectMeta *meta_v1.ObjectMeta, name string) {
+	if _, ok := objectMeta.Annotations[name]; !ok {
+		return
+	}
+	delete(objectMeta.Annotations, name)
+	if len(objectMeta.Annotations) <= 0 {
+		objectMeta.Annotations = nil
+	}
+}
@@ -0,0 +1,310 @@
+package v2
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"encoding/json"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestConvertRoundTripFromV1(t *testing.T) {
+	transitionTime := meta_v1.NewTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	tests := []struct {
+		name   string
+		modify func(definition *scalingv1.AutoscalingDefinition)
+	}{
+		{
+			name:   "plain metric",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {},
+		},
+		{
+			name: "spec durations and enums",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.ScaleTarget.TargetType = "replicaset"
+				definition.Spec.IntervalBetweenAutoscaling = "1m0s"
+				definition.Spec.InitializationPeriod = "30s"
+				definition.Spec.ReadinessDelay = "10s"
+				definition.Spec.Mode = "recommend"
+				definition.Spec.ScalingStep = 2
+				definition.Spec.DecisionHistoryLimit = 5
+			},
+		},
+		{
+			name: "time scale values",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.Metrics[0].ScaleValueType = "time"
+				definition.Spec.Metrics[0].ScaleDownValue = "2020-01-02T03:04:05Z"
+				definition.Spec.Metrics[0].ScaleUpValue = "2020-01-02T04:04:05Z"
+			},
+		},
+		{
+			name: "string scale values",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.Metrics[0].ScaleValueType = "string"
+				definition.Spec.Metrics[0].ScaleDownValue = "low"
+				definition.Spec.Metrics[0].ScaleUpValue = "high"
+			},
+		},
+		{
+			name: "trimmed mean and percentile",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.Metrics[0].TrimmedPercentage = 10
+				definition.Spec.Metrics[0].Percentile = 95
+			},
+		},
+		{
+			name: "ewma",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.Metrics[0].Algorithm = "ewma"
+				definition.Spec.Metrics[0].EwmaHalfLife = "5m0s"
+			},
+		},
+		{
+			name: "arimax",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				metric := &definition.Spec.Metrics[0]
+				metric.Algorithm = "arimax"
+				metric.AutoregresionDegree = 2
+				metric.AutoregressionCoefficients = []string{"0.5", "0.25"}
+				metric.MovingAverageDegree = 1
+				metric.MovingAverageCoefficients = []string{"0.1"}
+				metric.DifferencingOrder = 1
+				metric.Intercept = "1.5"
+				metric.SeasonalPeriod = 24
+				metric.SeasonalAutoregressionDegree = 1
+				metric.SeasonalAutoregressionCoefficients = []string{"0.3"}
+				metric.SeasonalMovingAverageDegree = 1
+				metric.SeasonalMovingAverageCoefficients = []string{"0.2"}
+				metric.SeasonalDifferencingOrder = 1
+				metric.ExogenousRegressorQuery = "sum(requests)"
+				metric.ExogenousRegressorCoefficient = "0.7"
+				metric.ExogenousRegressorMaxValue = "100"
+				metric.ExogenousRegressors = []scalingv1.AutoscalingDefinitionExogenousRegressor{
+					{Name: "events", Query: "sum(events)", Source: "prometheus", PrometheusPath: "http://events-prometheus:9090", Coefficient: "0.4", MaxValue: "50", Lag: 2, Aggregation: "median"},
+				}
+			},
+		},
+		{
+			name: "pid",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				metric := &definition.Spec.Metrics[0]
+				metric.Algorithm = "pid"
+				metric.TargetValue = "70"
+				metric.ProportionalGain = "0.5"
+				metric.IntegralGain = "0.1"
+				metric.DerivativeGain = "0.05"
+				metric.MaxReplicaDelta = 3
+			},
+		},
+		{
+			name: "queue",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				metric := &definition.Spec.Metrics[0]
+				metric.Algorithm = "queue"
+				metric.BacklogQuery = "sum(backlog)"
+				metric.ArrivalRateQuery = "sum(rate(arrived[1m]))"
+				metric.ProcessingRateQuery = "rate(processed[1m])"
+				metric.TargetDrainTime = "5m0s"
+			},
+		},
+		{
+			name: "panic",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				metric := &definition.Spec.Metrics[0]
+				metric.PanicThreshold = "2"
+				metric.PanicWindow = 3
+				metric.PanicStabilizationPeriod = "1m0s"
+			},
+		},
+		{
+			name: "external",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				metric := &definition.Spec.Metrics[0]
+				metric.Algorithm = "external"
+				metric.ExternalProtocol = "grpc"
+				metric.ExternalEndpoint = "algorithm:9000"
+				metric.ExternalTimeout = "5s"
+				metric.ExternalHistoryLength = 10
+			},
+		},
+		{
+			name: "schedules",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.Schedules = []scalingv1.AutoscalingDefinitionSchedule{
+					{Name: "business", Cron: "0 8 * * 1-5", Timezone: "Europe/Prague", Duration: "10h0m0s", MinReplicas: 2, MaxReplicas: 8},
+					{Name: "night", Cron: "0 22 * * *", Duration: "8h0m0s", Replicas: 1},
+				}
+			},
+		},
+		{
+			name: "activation, fallback and rollout",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				definition.Spec.ActivationMetric = scalingv1.AutoscalingDefinitionActivation{
+					PrometheusPath: "http://prometheus", PrometheusQuery: "sum(requests)", ActivationThreshold: "0.5",
+					ActivationReplicas: 1, IdlePeriod: "10m0s", ScrapeInterval: "30s",
+				}
+				definition.Spec.Fallback = scalingv1.AutoscalingDefinitionFallback{Behavior: "safeReplicas", FailureThreshold: 3, Replicas: 4}
+				definition.Spec.Rollout = scalingv1.AutoscalingDefinitionRollout{Policy: "defer", PostRolloutDelay: "2m0s"}
+			},
+		},
+		{
+			name: "status",
+			modify: func(definition *scalingv1.AutoscalingDefinition) {
+				decision := scalingv1.AutoscalingDefinitionDecision{
+					Time: transitionTime, Metric: "cpu", FromReplicas: 2, ToReplicas: 3, Reason: "scale up", Policies: []string{"step"},
+					Inputs: []scalingv1.AutoscalingDefinitionDecisionInput{{
+						Metric: "cpu", ScrapeCount: 6, Value: "81.5", NumOfTests: 3, LowerBoundTestsPassed: 0, UpperBoundTestsPassed: 3, PredictedValue: "90",
+						ExogenousRegressors: []scalingv1.AutoscalingDefinitionDecisionExogenousInput{{Name: "events", Value: "12"}},
+					}},
+				}
+				definition.Status = scalingv1.AutoscalingDefinitionStatus{
+					State:   "Active",
+					Message: "scaled",
+					Conditions: []scalingv1.AutoscalingDefinitionCondition{
+						{Type: "MetricsAvailable", Status: "True", LastTransitionTime: transitionTime, Reason: "Scraped"},
+					},
+					RecommendedReplicas:    3,
+					LastRecommendationTime: transitionTime,
+					LastScaleTime:          transitionTime,
+					CooldownUntil:          transitionTime,
+					LastDecision:           decision,
+					Decisions:              []scalingv1.AutoscalingDefinitionDecision{decision},
+					Metrics:                []scalingv1.AutoscalingDefinitionMetricStatus{{Name: "cpu", Value: "81.5", PredictedValue: "90", LastUpdateTime: transitionTime}},
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		definition := newV1Definition()
+		test.modify(definition)
+
+		converted := ConvertFromV1(definition)
+		if _, ok := converted.Annotations[UnconvertibleValuesAnnotation]; ok {
+			t.Errorf("ConvertFromV1() of %s kept unconvertible values %s", test.name, converted.Annotations[UnconvertibleValuesAnnotation])
+		}
+		assertSameJSON(t, test.name, ConvertToV1(converted), definition)
+	}
+}
+
+func TestConvertRoundTripFromV1KeepsUnparsableValues(t *testing.T) {
+	definition := newV1Definition()
+	definition.Spec.IntervalBetweenAutoscaling = "soon"
+	definition.Spec.ActivationMetric = scalingv1.AutoscalingDefinitionActivation{PrometheusQuery: "sum(requests)", ActivationThreshold: "half"}
+	definition.Spec.Schedules = []scalingv1.AutoscalingDefinitionSchedule{{Name: "night", Cron: "0 22 * * *", Duration: "all night"}}
+	metric := &definition.Spec.Metrics[0]
+	metric.ScaleValueType = "integer"
+	metric.ScaleUpValue = "many"
+	metric.EwmaHalfLife = "5 minutes"
+	metric.AutoregresionDegree = 2
+	metric.AutoregressionCoefficients = []string{"0.5", "a quarter"}
+	metric.ProportionalGain = "0,5"
+	definition.Status.Metrics = []scalingv1.AutoscalingDefinitionMetricStatus{{Name: "cpu", Value: "NaN?"}}
+
+	converted := ConvertFromV1(definition)
+	if _, ok := converted.Annotations[UnconvertibleValuesAnnotation]; !ok {
+		t.Fatalf("ConvertFromV1() did not keep unparsable values in %s annotation", UnconvertibleValuesAnnotation)
+	}
+	if converted.Spec.IntervalBetweenAutoscaling != nil || converted.Spec.Metrics[0].Ewma.HalfLife != nil {
+		t.Errorf("ConvertFromV1() = %+v, expected unparsable durations to be unset", converted.Spec)
+	}
+	if len(definition.Annotations) != 1 {
+		t.Errorf("ConvertFromV1() modified annotations of converted definition: %v", definition.Annotations)
+	}
+	assertSameJSON(t, "unparsable values", ConvertToV1(converted), definition)
+
+	raw, err := json.Marshal(converted)
+	if err != nil {
+		t.Fatalf("cannot marshal v2 definition: %s", err.Error())
+	}
+	stored := AutoscalingDefinition{}
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		t.Fatalf("cannot unmarshal v2 definition: %s", err.Error())
+	}
+	assertSameJSON(t, "unparsable values through json", ConvertToV1(&stored), definition)
+}
+
+func TestConvertToV1PrefersValuesChangedInV2(t *testing.T) {
+	definition := newV1Definition()
+	definition.Spec.IntervalBetweenAutoscaling = "soon"
+	definition.Spec.Metrics[0].EwmaHalfLife = "5 minutes"
+
+	converted := ConvertFromV1(definition)
+	converted.Spec.IntervalBetweenAutoscaling = &meta_v1.Duration{Duration: 2 * time.Minute}
+
+	result := ConvertToV1(converted)
+	if result.Spec.IntervalBetweenAutoscaling != "2m0s" {
+		t.Errorf("ConvertToV1() intervalBetweenAutoscaling = %q, expected value set in v2", result.Spec.IntervalBetweenAutoscaling)
+	}
+	if result.Spec.Metrics[0].EwmaHalfLife != "5 minutes" {
+		t.Errorf("ConvertToV1() ewmaHalfLife = %q, expected original value", result.Spec.Metrics[0].EwmaHalfLife)
+	}
+	if _, ok := result.Annotations[UnconvertibleValuesAnnotation]; ok {
+		t.Errorf("ConvertToV1() kept %s annotation", UnconvertibleValuesAnnotation)
+	}
+}
+
+func TestConvertRoundTripFromV2(t *testing.T) {
+	threshold := 0.5
+	maxValue := 100.0
+	definition := &AutoscalingDefinition{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "AutoscalingDefinition"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: AutoscalingDefinitionSpec{
+			ScaleTarget:                ScaleTarget{LabelName: "app", MatchLabel: "example", TargetType: TargetTypeDeployment},
+			MinReplicas:                1,
+			MaxReplicas:                10,
+			IntervalBetweenAutoscaling: &meta_v1.Duration{Duration: time.Minute},
+			Mode:                       ModeAuto,
+			Metrics: []Metric{{
+				Name: "cpu", MetricType: MetricTypePrometheus, PrometheusPath: "http://prometheus", PrometheusQuery: "avg(cpu)",
+				ScaleValueType: ScaleValueTypeDouble, ScaleDownValue: &threshold, ScaleUpValue: &maxValue, Algorithm: AlgorithmArimax,
+				Arimax: &ArimaxConfig{
+					AutoregressionDegree: 1, AutoregressionCoefficients: []float64{0.5}, Intercept: 2,
+					Seasonal:            &SeasonalConfig{Period: 24, AutoregressionDegree: 1, AutoregressionCoefficients: []float64{0.2}},
+					ExogenousRegressors: []ExogenousRegressor{{Name: "events", Query: "sum(events)", PrometheusPath: "http://events-prometheus", Coefficient: 0.3, MaxValue: &maxValue}},
+				},
+				Pid:      &PidConfig{TargetValue: 70, ProportionalGain: 0.5, MaxReplicaDelta: 2},
+				Queue:    &QueueConfig{BacklogQuery: "sum(backlog)", ProcessingRateQuery: "rate(processed[1m])", TargetDrainTime: &meta_v1.Duration{Duration: 5 * time.Minute}},
+				Panic:    &PanicConfig{Threshold: 2, Window: 3},
+				External: &ExternalConfig{Protocol: ExternalProtocolHttp, Endpoint: "http://algorithm", Timeout: &meta_v1.Duration{Duration: 5 * time.Second}},
+			}},
+			Schedules:        []Schedule{{Name: "night", Cron: "0 22 * * *", Duration: meta_v1.Duration{Duration: 8 * time.Hour}, Replicas: 1}},
+			ActivationMetric: &Activation{PrometheusQuery: "sum(requests)", ActivationThreshold: &threshold},
+			Fallback:         &Fallback{Behavior: FallbackBehaviorHold, FailureThreshold: 3},
+			Rollout:          &Rollout{Policy: RolloutPolicyCap},
+		},
+	}
+	assertSameJSON(t, "v2 definition", ConvertFromV1(ConvertToV1(definition)), definition)
+}
+
+func newV1Definition() *scalingv1.AutoscalingDefinition {
+	return &scalingv1.AutoscalingDefinition{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: scalingv1.SchemeGroupVersion.String(), Kind: "AutoscalingDefinition"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: "example", Namespace: "default", Annotations: map[string]string{"owner": "team"}},
+		Spec: scalingv1.AutoscalingDefinitionSpec{
+			ScaleTarget: scalingv1.AutoscalingDefinitionScaleTarget{LabelName: "app", MatchLabel: "example", TargetType: "deployment"},
+			MinReplicas: 1,
+			MaxReplicas: 10,
+			Metrics: []scalingv1.AutoscalingDefinitionMetric{{
+				Name: "cpu", MetricType: "prometheus", PrometheusPath: "http://prometheus", PrometheusQuery: "avg(cpu)",
+				ScaleDownValue: "20", ScaleUpValue: "80.5", ScaleValueType: "double", ValueScope: "perReplica", NumOfTests: 3,
+				Algorithm: "mean", PercentageOfTestConditionFulfillment: 100, ScrapeInterval: "10s", TestInterval: "1m0s",
+			}},
+		},
+	}
+}
+
+func assertSameJSON(t *testing.T, name string, actual interface{}, expected interface{}) {
+	actualRaw, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatalf("%s: cannot marshal result: %s", name, err.Error())
+	}
+	expectedRaw, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatalf("%s: cannot marshal expected value: %s", name, err.Error())
+	}
+	if string(actualRaw) != string(expectedRaw) {
+		t.Errorf("%s: round trip = %s, expected %s", name, actualRaw, expectedRaw)
+	}
+}
@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package
+// +groupName=scaling.com
+
+// Package v2 is the strongly typed v2 version of the scaling.com API. It is converted from and to
+// storage version v1 by conversion webhook.
+package v2
@@ -0,0 +1,326 @@
+package v2
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TargetType string
+
+const (
+	TargetTypeDeployment TargetType = "deployment"
+	TargetTypeReplicaSet TargetType = "replicaset"
+)
+
+type Mode string
+
+const (
+	ModeAuto      Mode = "auto"
+	ModeRecommend Mode = "recommend"
+)
+
+type MetricType string
+
+const (
+	MetricTypePrometheus MetricType = "prometheus"
+)
+
+type ScaleValueType string
+
+const (
+	ScaleValueTypeInteger ScaleValueType = "integer"
+	ScaleValueTypeDouble  ScaleValueType = "double"
+	ScaleValueTypeBoolean ScaleValueType = "boolean"
+	ScaleValueTypeTime    ScaleValueType = "time"
+	ScaleValueTypeString  ScaleValueType = "string"
+)
+
+type ValueScope string
+
+const (
+	ValueScopePerReplica ValueScope = "perReplica"
+	ValueScopeTotal      ValueScope = "total"
+)
+
+type Algorithm string
+
+const (
+	AlgorithmDefault     Algorithm = "default"
+	AlgorithmMean        Algorithm = "mean"
+	AlgorithmMedian      Algorithm = "median"
+	AlgorithmTrimmedMean Algorithm = "trimmedmean"
+	AlgorithmPercentile  Algorithm = "percentile"
+	AlgorithmMax         Algorithm = "max"
+	AlgorithmMin         Algorithm = "min"
+	AlgorithmEwma        Algorithm = "ewma"
+	AlgorithmArimax      Algorithm = "arimax"
+	AlgorithmPid         Algorithm = "pid"
+	AlgorithmQueue       Algorithm = "queue"
+	AlgorithmExternal    Algorithm = "external"
+)
+
+type ExternalProtocol string
+
+const (
+	ExternalProtocolHttp ExternalProtocol = "http"
+	ExternalProtocolGrpc ExternalProtocol = "grpc"
+)
+
+type ExogenousAggregation string
+
+const (
+	ExogenousAggregationTrimmedMean ExogenousAggregation = "trimmedmean"
+	ExogenousAggregationMean        ExogenousAggregation = "mean"
+	ExogenousAggregationMedian      ExogenousAggregation = "median"
+	ExogenousAggregationMax         ExogenousAggregation = "max"
+	ExogenousAggregationMin         ExogenousAggregation = "min"
+	ExogenousAggregationLast        ExogenousAggregation = "last"
+)
+
+type FallbackBehavior string
+
+const (
+	FallbackBehaviorHold         FallbackBehavior = "hold"
+	FallbackBehaviorSafeReplicas FallbackBehavior = "safeReplicas"
+	FallbackBehaviorScaleUp      FallbackBehavior = "scaleUp"
+)
+
+type RolloutPolicy string
+
+const (
+	RolloutPolicyIgnore RolloutPolicy = "ignore"
+	RolloutPolicyDefer  RolloutPolicy = "defer"
+	RolloutPolicyCap    RolloutPolicy = "cap"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AutoscalingDefinition struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata"`
+	Spec               AutoscalingDefinitionSpec   `json:"spec"`
+	Status             AutoscalingDefinitionStatus `json:"status,omitempty"`
+}
+
+type AutoscalingDefinitionSpec struct {
+	ScaleTarget                ScaleTarget       `json:"scaleTarget"`
+	MinReplicas                int32             `json:"minReplicas,omitempty"`
+	MaxReplicas                int32             `json:"maxReplicas,omitempty"`
+	IntervalBetweenAutoscaling *meta_v1.Duration `json:"intervalBetweenAutoscaling,omitempty"`
+	ScalingStep                int32             `json:"scalingStep,omitempty"`
+	Mode                       Mode              `json:"mode,omitempty"`
+	InitializationPeriod       *meta_v1.Duration `json:"initializationPeriod,omitempty"`
+	ReadinessDelay             *meta_v1.Duration `json:"readinessDelay,omitempty"`
+	Metrics                    []Metric          `json:"metrics"`
+	Schedules                  []Schedule        `json:"schedules,omitempty"`
+	ActivationMetric           *Activation       `json:"activationMetric,omitempty"`
+	Fallback                   *Fallback         `json:"fallback,omitempty"`
+	Rollout                    *Rollout          `json:"rollout,omitempty"`
+	DecisionHistoryLimit       int32             `json:"decisionHistoryLimit,omitempty"`
+}
+
+type ScaleTarget struct {
+	MatchNamespace string     `json:"matchNamespace,omitempty"`
+	LabelName      string     `json:"labelName"`
+	MatchLabel     string     `json:"matchLabel"`
+	TargetType     TargetType `json:"targetType,omitempty"`
+}
+
+// Metric holds bounds of scaling in field matching its scaleValueType: numeric bounds for integer and double,
+// time bounds for time and string bounds for string and boolean
+type Metric struct {
+	Name                                 string             `json:"name"`
+	MetricType                           MetricType         `json:"metricType"`
+	PrometheusPath                       string             `json:"prometheusPath,omitempty"`
+	PrometheusQuery                      string             `json:"prometheusQuery,omitempty"`
+	ScaleValueType                       ScaleValueType     `json:"scaleValueType,omitempty"`
+	ScaleDownValue                       *float64           `json:"scaleDownValue,omitempty"`
+	ScaleUpValue                         *float64           `json:"scaleUpValue,omitempty"`
+	ScaleDownTime                        *meta_v1.Time      `json:"scaleDownTime,omitempty"`
+	ScaleUpTime                          *meta_v1.Time      `json:"scaleUpTime,omitempty"`
+	ScaleDownString                      string             `json:"scaleDownString,omitempty"`
+	ScaleUpString                        string             `json:"scaleUpString,omitempty"`
+	ValueScope                           ValueScope         `json:"valueScope,omitempty"`
+	NumOfTests                           int32              `json:"numOfTests,omitempty"`
+	Algorithm                            Algorithm          `json:"algorithm,omitempty"`
+	PercentageOfTestConditionFulfillment int32              `json:"percentageOfTestConditionFulfillment,omitempty"`
+	ScrapeInterval                       *meta_v1.Duration  `json:"scrapeInterval,omitempty"`
+	TestInterval                         *meta_v1.Duration  `json:"testInterval,omitempty"`
+	TrimmedMean                          *TrimmedMeanConfig `json:"trimmedMean,omitempty"`
+	Percentile                           *PercentileConfig  `json:"percentile,omitempty"`
+	Ewma                                 *EwmaConfig        `json:"ewma,omitempty"`
+	Arimax                               *ArimaxConfig      `json:"arimax,omitempty"`
+	Pid                                  *PidConfig         `json:"pid,omitempty"`
+	Queue                                *QueueConfig       `json:"queue,omitempty"`
+	Panic                                *PanicConfig       `json:"panic,omitempty"`
+	External                             *ExternalConfig    `json:"external,omitempty"`
+}
+
+// TrimmedMeanConfig is used by trimmedmean algorithm and by robust mean of arimax and pid algorithms
+type TrimmedMeanConfig struct {
+	Percentage int32 `json:"percentage"`
+}
+
+type PercentileConfig struct {
+	Percentile int32 `json:"percentile"`
+}
+
+type EwmaConfig struct {
+	HalfLife *meta_v1.Duration `json:"halfLife,omitempty"`
+}
+
+type ArimaxConfig struct {
+	AutoregressionDegree       int32                `json:"autoregressionDegree,omitempty"`
+	AutoregressionCoefficients []float64            `json:"autoregressionCoefficients,omitempty"`
+	MovingAverageDegree        int32                `json:"movingAverageDegree,omitempty"`
+	MovingAverageCoefficients  []float64            `json:"movingAverageCoefficients,omitempty"`
+	ExogenousRegressor         *ExogenousRegressor  `json:"exogenousRegressor,omitempty"`
+	ExogenousRegressors        []ExogenousRegressor `json:"exogenousRegressors,omitempty"`
+	DifferencingOrder          int32                `json:"differencingOrder,omitempty"`
+	Intercept                  float64              `json:"intercept,omitempty"`
+	Seasonal                   *SeasonalConfig      `json:"seasonal,omitempty"`
+}
+
+// SeasonalConfig sets seasonal terms of arimax model, period is number of test intervals
+type SeasonalConfig struct {
+	Period                     int32     `json:"period"`
+	AutoregressionDegree       int32     `json:"autoregressionDegree,omitempty"`
+	AutoregressionCoefficients []float64 `json:"autoregressionCoefficients,omitempty"`
+	MovingAverageDegree        int32     `json:"movingAverageDegree,omitempty"`
+	MovingAverageCoefficients  []float64 `json:"movingAverageCoefficients,omitempty"`
+	DifferencingOrder          int32     `json:"differencingOrder,omitempty"`
+}
+
+// ExogenousRegressor without name is the single regressor of exogenousRegressor field, name, source, lag and aggregation
+// are set by regressors of exogenousRegressors list
+type ExogenousRegressor struct {
+	Name           string               `json:"name,omitempty"`
+	Query          string               `json:"query"`
+	Source         MetricType           `json:"source,omitempty"`
+	PrometheusPath string               `json:"prometheusPath,omitempty"`
+	Coefficient    float64              `json:"coefficient,omitempty"`
+	MaxValue       *float64             `json:"maxValue,omitempty"`
+	Lag            int32                `json:"lag,omitempty"`
+	Aggregation    ExogenousAggregation `json:"aggregation,omitempty"`
+}
+
+type PidConfig struct {
+	TargetValue      float64 `json:"targetValue"`
+	ProportionalGain float64 `json:"proportionalGain,omitempty"`
+	IntegralGain     float64 `json:"integralGain,omitempty"`
+	DerivativeGain   float64 `json:"derivativeGain,omitempty"`
+	MaxReplicaDelta  int32   `json:"maxReplicaDelta,omitempty"`
+}
+
+type QueueConfig struct {
+	BacklogQuery        string            `json:"backlogQuery"`
+	ArrivalRateQuery    string            `json:"arrivalRateQuery"`
+	ProcessingRateQuery string            `json:"processingRateQuery"`
+	TargetDrainTime     *meta_v1.Duration `json:"targetDrainTime,omitempty"`
+}
+
+type PanicConfig struct {
+	Threshold           float64           `json:"threshold"`
+	Window              int32             `json:"window,omitempty"`
+	StabilizationPeriod *meta_v1.Duration `json:"stabilizationPeriod,omitempty"`
+}
+
+// ExternalConfig configures endpoint of external algorithm, exogenous regressor of arimax config is sent to it when set
+type ExternalConfig struct {
+	Protocol      ExternalProtocol  `json:"protocol,omitempty"`
+	Endpoint      string            `json:"endpoint"`
+	Timeout       *meta_v1.Duration `json:"timeout,omitempty"`
+	HistoryLength int32             `json:"historyLength,omitempty"`
+}
+
+type Schedule struct {
+	Name        string           `json:"name"`
+	Cron        string           `json:"cron"`
+	Timezone    string           `json:"timezone,omitempty"`
+	Duration    meta_v1.Duration `json:"duration"`
+	MinReplicas int32            `json:"minReplicas,omitempty"`
+	MaxReplicas int32            `json:"maxReplicas,omitempty"`
+	Replicas    int32            `json:"replicas,omitempty"`
+}
+
+type Activation struct {
+	PrometheusPath      string            `json:"prometheusPath,omitempty"`
+	PrometheusQuery     string            `json:"prometheusQuery"`
+	ActivationThreshold *float64          `json:"activationThreshold,omitempty"`
+	ActivationReplicas  int32             `json:"activationReplicas,omitempty"`
+	IdlePeriod          *meta_v1.Duration `json:"idlePeriod,omitempty"`
+	ScrapeInterval      *meta_v1.Duration `json:"scrapeInterval,omitempty"`
+}
+
+type Fallback struct {
+	Behavior         FallbackBehavior `json:"behavior"`
+	FailureThreshold int32            `json:"failureThreshold,omitempty"`
+	Replicas         int32            `json:"replicas,omitempty"`
+}
+
+type Rollout struct {
+	Policy           RolloutPolicy     `json:"policy,omitempty"`
+	PostRolloutDelay *meta_v1.Duration `json:"postRolloutDelay,omitempty"`
+}
+
+type AutoscalingDefinitionStatus struct {
+	State                  string         `json:"state,omitempty"`
+	Message                string         `json:"message,omitempty"`
+	Conditions             []Condition    `json:"conditions,omitempty"`
+	RecommendedReplicas    int32          `json:"recommendedReplicas,omitempty"`
+	LastRecommendationTime meta_v1.Time   `json:"lastRecommendationTime,omitempty"`
+	LastScaleTime          meta_v1.Time   `json:"lastScaleTime,omitempty"`
+	CooldownUntil          meta_v1.Time   `json:"cooldownUntil,omitempty"`
+	LastDecision           *Decision      `json:"lastDecision,omitempty"`
+	Metrics                []MetricStatus `json:"metrics,omitempty"`
+	Decisions              []Decision     `json:"decisions,omitempty"`
+}
+
+type Decision struct {
+	Time         meta_v1.Time    `json:"time,omitempty"`
+	Metric       string          `json:"metric,omitempty"`
+	FromReplicas int32           `json:"fromReplicas,omitempty"`
+	ToReplicas   int32           `json:"toReplicas,omitempty"`
+	Reason       string          `json:"reason,omitempty"`
+	Policies     []string        `json:"policies,omitempty"`
+	Inputs       []DecisionInput `json:"inputs,omitempty"`
+}
+
+type DecisionInput struct {
+	Metric                string                   `json:"metric"`
+	ScrapeCount           int32                    `json:"scrapeCount,omitempty"`
+	Value                 *float64                 `json:"value,omitempty"`
+	NumOfTests            int32                    `json:"numOfTests,omitempty"`
+	LowerBoundTestsPassed int32                    `json:"lowerBoundTestsPassed,omitempty"`
+	UpperBoundTestsPassed int32                    `json:"upperBoundTestsPassed,omitempty"`
+	PredictedValue        *float64                 `json:"predictedValue,omitempty"`
+	ExogenousRegressors   []DecisionExogenousInput `json:"exogenousRegressors,omitempty"`
+}
+
+type DecisionExogenousInput struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+type MetricStatus struct {
+	Name                  string       `json:"name"`
+	Value                 *float64     `json:"value,omitempty"`
+	PredictedValue        *float64     `json:"predictedValue,omitempty"`
+	HistoryLength         int32        `json:"historyLength,omitempty"`
+	RequiredHistoryLength int32        `json:"requiredHistoryLength,omitempty"`
+	LastUpdateTime        meta_v1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type Condition struct {
+	Type               string       `json:"type"`
+	Status             string       `json:"status"`
+	LastTransitionTime meta_v1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string       `json:"reason,omitempty"`
+	Message            string       `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AutoscalingDefinitionList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AutoscalingDefinition `json:"items"`
+}
@@ -0,0 +1,51 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"custom-hpa/util"
+	"log"
+	"strconv"
+	"time"
+)
+
+func isActivationEnabled(spec scalingv1.AutoscalingDefinitionSpec) bool {
+	return len(spec.ActivationMetric.PrometheusQuery) > 0
+}
+
+func fillActivationDefaultValues(activation *scalingv1.AutoscalingDefinitionActivation) {
+	if _, err := strconv.ParseFloat(activation.ActivationThreshold, 64); err != nil {
+		activation.ActivationThreshold = "0"
+	}
+	if activation.ActivationReplicas <= 0 {
+		activation.ActivationReplicas = 1
+	}
+	if _, err := time.ParseDuration(activation.IdlePeriod); len(activation.IdlePeriod) <= 0 || err != nil {
+		activation.IdlePeriod = "5m"
+	}
+	if _, err := time.ParseDuration(activation.ScrapeInterval); len(activation.ScrapeInterval) <= 0 || err != nil {
+		activation.ScrapeInterval = "30s"
+	}
+}
+
+// scrapeActivationMetric periodically reads activation metric, an empty result is treated as no activity.
+// Value is dropped, when autoscale process is closed.
+func scrapeActivationMetric(activation scalingv1.AutoscalingDefinitionActivation, closeAutoscaleProcessChannel chan bool, clock util.Clock) (activationChannel chan float64, scrapeInterval chan bool) {
+	activationChannel = make(chan float64)
+	scrapeDuration, _ := time.ParseDuration(activation.ScrapeInterval)
+	scrapeInterval = clock.SetInterval(func() {
+		value, err := metrics.ReadScalarMetric(metrics.MetricTypePrometheus, metrics.MetricSourceSpec{Address: activation.PrometheusPath, Query: activation.PrometheusQuery, Time: clock.Now()})
+		if err == metrics.ErrEmptyMetric {
+			value, err = 0, nil
+		}
+		if err != nil {
+			log.Printf("Activation metric error: %s", err.Error())
+			return
+		}
+		select {
+		case activationChannel <- value:
+		case <-closeAutoscaleProcessChannel:
+		}
+	}, scrapeDuration, false)
+	return
+}
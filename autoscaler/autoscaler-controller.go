@@ -1,30 +1,38 @@
 package autoscaler
 
 import (
-	"custom-hpa/clients"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/metrics"
-	"custom-hpa/model"
+	"custom-hpa/pkg/client/clientset/versioned"
+	"custom-hpa/util"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"log"
-	"strings"
 	"time"
 )
 
+type AutoscalerOptions struct {
+	DryRun bool
+	// Clock drives metric pipelines and autoscaling process, real clock is used when not set
+	Clock util.Clock
+}
+
 type DefinitionChanges struct {
-	definitionsToAdd    []model.AutoscalingDefinition
-	definitionsToRemove []model.AutoscalingDefinition
+	definitionsToAdd    []scalingv1.AutoscalingDefinition
+	definitionsToRemove []scalingv1.AutoscalingDefinition
 }
 
 type DefinitionChannel struct {
-	definition                     model.AutoscalingDefinition
+	definition                     scalingv1.AutoscalingDefinition
 	metricChannels                 []MetricChannels
 	mainAutoscaleEvaluationChannel chan AutoscaleEvaluation
-	clearMetricBufferChannel       chan model.AutoscalingDefinitionMetric
+	clearMetricBufferChannel       chan scalingv1.AutoscalingDefinitionMetric
+	annotations                    *definitionAnnotations
+	autoscaleProcess               AutoscaleProcessResult
 }
 
 type MetricChannels struct {
-	metric                          model.AutoscalingDefinitionMetric
+	metric                          scalingv1.AutoscalingDefinitionMetric
 	testResultsChannel              chan metrics.TestResult
 	scrapeInterval                  chan bool
 	testInterval                    chan bool
@@ -36,12 +44,12 @@ type MetricChannels struct {
 	exogenousScrapeInterval         chan bool
 }
 
-func MainAutoscalingLoop(client *clients.Client, extensionsClient *kubernetes.Clientset) {
-	var newDefinitions []model.AutoscalingDefinition
+func MainAutoscalingLoop(client versioned.Interface, extensionsClient *kubernetes.Clientset, options AutoscalerOptions) {
+	var newDefinitions []scalingv1.AutoscalingDefinition
 	var channels []DefinitionChannel
 	for {
 		oldDefinitions := newDefinitions
-		def, err := client.AutoscalerDefinitions("default").List(meta_v1.ListOptions{})
+		def, err := client.ScalingV1().AutoscalingDefinitions("default").List(meta_v1.ListOptions{})
 		if err != nil {
 			log.Printf("Error %s", err.Error())
 			continue
@@ -51,9 +59,10 @@ func MainAutoscalingLoop(client *clients.Client, extensionsClient *kubernetes.Cl
 		}
 		newDefinitions = def.Items
 		changes := detectDefinitionChanges(newDefinitions, oldDefinitions)
+		refreshAnnotations(newDefinitions, channels)
 
 		if changes.definitionsToAdd != nil {
-			addedChannels := addDefinitions(changes.definitionsToAdd, extensionsClient)
+			addedChannels := addDefinitions(changes.definitionsToAdd, extensionsClient, client, options)
 			channels = append(channels, addedChannels...)
 		}
 		if changes.definitionsToRemove != nil {
@@ -64,7 +73,7 @@ func MainAutoscalingLoop(client *clients.Client, extensionsClient *kubernetes.Cl
 	}
 }
 
-func addDefinitions(definitions []model.AutoscalingDefinition, client *kubernetes.Clientset) []DefinitionChannel {
+func addDefinitions(definitions []scalingv1.AutoscalingDefinition, client *kubernetes.Clientset, definitionClient versioned.Interface, options AutoscalerOptions) []DefinitionChannel {
 	var result []DefinitionChannel
 	for _, definition := range definitions {
 		if &definition == nil {
@@ -80,50 +89,42 @@ func addDefinitions(definitions []model.AutoscalingDefinition, client *kubernete
 			definition:                     definition,
 			metricChannels:                 make([]MetricChannels, len(definition.Spec.Metrics)),
 			mainAutoscaleEvaluationChannel: make(chan AutoscaleEvaluation),
-			clearMetricBufferChannel:       make(chan model.AutoscalingDefinitionMetric),
+			clearMetricBufferChannel:       make(chan scalingv1.AutoscalingDefinitionMetric),
+			annotations:                    newDefinitionAnnotations(definition.Annotations),
+		}
+		clock := util.ClockOrReal(options.Clock)
+		targetProviders := metrics.TargetProviders{
+			PodStates:    newPodStateProvider(client, definition, clock),
+			ReplicaCount: newReplicaCountProvider(client, definition, clock),
+			Clock:        clock,
 		}
 		for i, metric := range definition.Spec.Metrics {
-			scrapeResultChannel, err := metrics.MakeScrape(metric)
-			if err != nil {
-				log.Printf("Scrape error: %s", err.Error())
-				continue
-			}
-			testResultsChannel, err := metrics.MakeTest(metric, scrapeResultChannel)
+			pipeline, err := StartMetricPipeline(metric, targetProviders)
 			if err != nil {
-				log.Printf("Test error: %s", err.Error())
+				log.Printf("Metric pipeline error: %s", err.Error())
 				continue
 			}
-			exogenousRegressorResultChannel := ExogenousRegressorResultChannel{}
-			if strings.ToUpper(metric.Algorithm) == "ARIMAX" {
-				exogenousRegressorResultChannel, err = CollectExogenousMetrics(metric)
-				if err != nil {
-					log.Printf("Test error: %s", err.Error())
-					continue
-				}
-			}
-
-			autoscaleEvaluationResult := EvaluateAutoscaling(testResultsChannel, exogenousRegressorResultChannel.exogenousRegressorResultChannel, metric)
 			channel.metricChannels[i] = MetricChannels{
 				metric:                          metric,
-				testResultsChannel:              testResultsChannel.TestResultsChannel,
-				scrapeInterval:                  testResultsChannel.ScrapeInterval,
-				testInterval:                    testResultsChannel.TestInterval,
-				autoscaleEvaluation:             autoscaleEvaluationResult.AutoscaleEvaluation,
-				closeEvaluationProcessChannel:   autoscaleEvaluationResult.CloseEvaluationProcessChannel,
-				closeRewriteChannel:             rewriteToMainChannel(autoscaleEvaluationResult, channel.mainAutoscaleEvaluationChannel),
-				clearChannel:                    autoscaleEvaluationResult.ClearBufferChannel,
-				exogenousRegressorResultChannel: exogenousRegressorResultChannel.exogenousRegressorResultChannel,
-				exogenousScrapeInterval:         exogenousRegressorResultChannel.scrapeInterval,
+				testResultsChannel:              pipeline.TestResultsChannel,
+				scrapeInterval:                  pipeline.ScrapeInterval,
+				testInterval:                    pipeline.TestInterval,
+				autoscaleEvaluation:             pipeline.Evaluation.AutoscaleEvaluation,
+				closeEvaluationProcessChannel:   pipeline.Evaluation.CloseEvaluationProcessChannel,
+				closeRewriteChannel:             rewriteToMainChannel(pipeline.Evaluation, channel.mainAutoscaleEvaluationChannel),
+				clearChannel:                    pipeline.Evaluation.ClearBufferChannel,
+				exogenousRegressorResultChannel: pipeline.ExogenousRegressorResultChannel,
+				exogenousScrapeInterval:         pipeline.ExogenousScrapeInterval,
 			}
 		}
-		result = append(result, channel)
 		rewriteToConcreteClearBufferChannel(channel.clearMetricBufferChannel, channel.metricChannels)
-		StartAutoscaleProcess(channel.mainAutoscaleEvaluationChannel, client, definition, channel.clearMetricBufferChannel)
+		channel.autoscaleProcess = StartAutoscaleProcess(channel.mainAutoscaleEvaluationChannel, client, definitionClient, definition, channel.annotations, channel.clearMetricBufferChannel, options)
+		result = append(result, channel)
 	}
 	return result
 }
 
-func rewriteToConcreteClearBufferChannel(clearMetricBufferChannel chan model.AutoscalingDefinitionMetric, metricChannels []MetricChannels) {
+func rewriteToConcreteClearBufferChannel(clearMetricBufferChannel chan scalingv1.AutoscalingDefinitionMetric, metricChannels []MetricChannels) {
 	go func() {
 		for {
 			select {
@@ -153,17 +154,23 @@ func rewriteToMainChannel(autoscaleEvaluationResult AutoscaleEvaluationResult, m
 	return closeChannel
 }
 
-func removeDefinitions(definitions []model.AutoscalingDefinition, channels []DefinitionChannel) []DefinitionChannel {
+func removeDefinitions(definitions []scalingv1.AutoscalingDefinition, channels []DefinitionChannel) []DefinitionChannel {
 	var result []DefinitionChannel
 	for _, def := range definitions {
 		log.Printf("Removing definition for: %s", def.Spec.ScaleTarget.MatchLabel)
 		var channel *DefinitionChannel
-		for _, ch := range channels {
-			if &def == &ch.definition {
-				channel = &ch
+		for i := range channels {
+			if def.Name == channels[i].definition.Name && def.Namespace == channels[i].definition.Namespace {
+				channel = &channels[i]
 			}
 		}
 		if channel != nil {
+			if channel.autoscaleProcess.ScheduleInterval != nil {
+				close(channel.autoscaleProcess.ScheduleInterval)
+			}
+			if channel.autoscaleProcess.ActivationScrapeInterval != nil {
+				close(channel.autoscaleProcess.ActivationScrapeInterval)
+			}
 			for _, mc := range channel.metricChannels {
 				if mc.scrapeInterval != nil {
 					mc.scrapeInterval <- true
@@ -190,13 +197,28 @@ func removeDefinitions(definitions []model.AutoscalingDefinition, channels []Def
 					close(mc.exogenousRegressorResultChannel)
 				}
 			}
+			if channel.autoscaleProcess.CloseAutoscaleProcessChannel != nil {
+				close(channel.autoscaleProcess.CloseAutoscaleProcessChannel)
+			}
 			result = append(result, *channel)
 		}
 	}
 	return result
 }
 
-func detectDefinitionChanges(newDefinitions []model.AutoscalingDefinition, oldDefinitions []model.AutoscalingDefinition) DefinitionChanges {
+// refreshAnnotations passes annotations of listed definitions to running autoscale processes, so that scaling control
+// annotations are read without request to API server
+func refreshAnnotations(definitions []scalingv1.AutoscalingDefinition, channels []DefinitionChannel) {
+	for _, definition := range definitions {
+		for _, channel := range channels {
+			if definition.Name == channel.definition.Name && definition.Namespace == channel.definition.Namespace && channel.annotations != nil {
+				channel.annotations.set(definition.Annotations)
+			}
+		}
+	}
+}
+
+func detectDefinitionChanges(newDefinitions []scalingv1.AutoscalingDefinition, oldDefinitions []scalingv1.AutoscalingDefinition) DefinitionChanges {
 	if oldDefinitions == nil || len(oldDefinitions) <= 0 {
 		return DefinitionChanges{
 			definitionsToAdd:    newDefinitions,
@@ -209,7 +231,7 @@ func detectDefinitionChanges(newDefinitions []model.AutoscalingDefinition, oldDe
 			definitionsToRemove: oldDefinitions,
 		}
 	}
-	var definitionsToRemove []model.AutoscalingDefinition
+	var definitionsToRemove []scalingv1.AutoscalingDefinition
 	for _, old := range oldDefinitions {
 		var found = false
 		for _, n := range newDefinitions {
@@ -221,7 +243,7 @@ func detectDefinitionChanges(newDefinitions []model.AutoscalingDefinition, oldDe
 			definitionsToRemove = append(definitionsToRemove, old)
 		}
 	}
-	var definitionsToAdd []model.AutoscalingDefinition
+	var definitionsToAdd []scalingv1.AutoscalingDefinition
 	for _, n := range newDefinitions {
 		var found = false
 		for _, old := range oldDefinitions {
@@ -246,8 +268,8 @@ func removeAllMatchingItemsFromArray(array []DefinitionChannel, itemsToDelete []
 	var result []DefinitionChannel
 	for _, item := range array {
 		var toDelete = false
-		for _, itemsToDelete := range array {
-			if &item == &itemsToDelete {
+		for _, itemToDelete := range itemsToDelete {
+			if item.definition.Name == itemToDelete.definition.Name && item.definition.Namespace == itemToDelete.definition.Namespace {
 				toDelete = true
 				break
 			}
@@ -1,14 +1,15 @@
 package autoscaler
 
 import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/clients"
 	"custom-hpa/metrics"
-	"custom-hpa/model"
+	"custom-hpa/pkg/client/clientset/versioned"
 	"custom-hpa/util"
 	"k8s.io/client-go/kubernetes"
 	"log"
 	"math"
-	"strings"
+	"strconv"
 	"time"
 )
 
@@ -18,75 +19,254 @@ type AutoscaleEvaluationResult struct {
 	ClearBufferChannel            chan bool
 }
 
+// AutoscaleProcessResult holds channels, which are closed to stop autoscale process and its timers
+type AutoscaleProcessResult struct {
+	CloseAutoscaleProcessChannel chan bool
+	ScheduleInterval             chan bool
+	ActivationScrapeInterval     chan bool
+}
+
 type AutoscaleEvaluation struct {
-	ScaleDown bool
-	ScaleUp   bool
-	Metric    model.AutoscalingDefinitionMetric
+	ScaleDown             bool
+	ScaleUp               bool
+	ReplicaDelta          int
+	DesiredReplicas       int
+	HasDesiredReplicas    bool
+	MetricUnavailable     bool
+	Panic                 bool
+	PanicRatio            float64
+	MetricValue           float64
+	HasMetricValue        bool
+	PredictedValue        float64
+	HasPredictedValue     bool
+	HistoryLength         int
+	RequiredHistoryLength int
+	ScrapeCount           int
+	NumOfTests            int
+	LowerBoundTests       int
+	UpperBoundTests       int
+	ExogenousRegressors   []ExogenousRegressorValue
+	Metric                scalingv1.AutoscalingDefinitionMetric
 }
 
 func EvaluateAutoscaling(resultChannel metrics.TestResultsChannel,
 	exogenousRegressorResultChannel chan ExogenousRegressorScrapeResult,
-	metric model.AutoscalingDefinitionMetric) AutoscaleEvaluationResult {
-	switch strings.ToUpper(metric.Algorithm) {
-	case "ARIMAX":
-		return EvaluateAutoscalingPredictive(resultChannel, exogenousRegressorResultChannel, metric)
-	default:
-		return EvaluateAutoscalingReactive(resultChannel, metric)
+	metric scalingv1.AutoscalingDefinitionMetric, clock util.Clock) AutoscaleEvaluationResult {
+	return startEvaluationProcess(NewEvaluator(metric), resultChannel, exogenousRegressorResultChannel, clock)
+}
+
+// startEvaluationProcess feeds evaluator with test results, exogenous regressor is read for every test result when its channel is set.
+func startEvaluationProcess(evaluator Evaluator, resultChannel metrics.TestResultsChannel,
+	exogenousRegressorResultChannel chan ExogenousRegressorScrapeResult, clock util.Clock) AutoscaleEvaluationResult {
+	clock = util.ClockOrReal(clock)
+	autoscaleEvaluationChannel := make(chan AutoscaleEvaluation)
+	closeEvaluationProcessChannel := make(chan bool)
+	clearBufferChannel := make(chan bool)
+	go func() {
+		for {
+			select {
+			case testResult := <-resultChannel.TestResultsChannel:
+				var exogenousRegressor ExogenousRegressorScrapeResult
+				if exogenousRegressorResultChannel != nil {
+					exogenousRegressor = <-exogenousRegressorResultChannel
+				}
+				autoscaleEvaluationChannel <- evaluator.Evaluate(testResult, exogenousRegressor, clock.Now())
+			case <-closeEvaluationProcessChannel:
+				return
+			case <-clearBufferChannel:
+				evaluator.Clear()
+			}
+		}
+	}()
+	return AutoscaleEvaluationResult{
+		AutoscaleEvaluation:           autoscaleEvaluationChannel,
+		CloseEvaluationProcessChannel: closeEvaluationProcessChannel,
+		ClearBufferChannel:            clearBufferChannel,
 	}
 }
 
-func StartAutoscaleProcess(autoscaleEvaluationChannel chan AutoscaleEvaluation, client *kubernetes.Clientset,
-	definition model.AutoscalingDefinition, clearMetricBufferChannel chan model.AutoscalingDefinitionMetric) {
-	fillDefinitionsDefaultValues(&definition)
+func StartAutoscaleProcess(autoscaleEvaluationChannel chan AutoscaleEvaluation, client *kubernetes.Clientset, definitionClient versioned.Interface,
+	definition scalingv1.AutoscalingDefinition, annotations *definitionAnnotations, clearMetricBufferChannel chan scalingv1.AutoscalingDefinitionMetric, options AutoscalerOptions) AutoscaleProcessResult {
+	FillDefinitionDefaultValues(&definition)
+	clock := util.ClockOrReal(options.Clock)
+	scaler := newTargetScaler(client, definitionClient, definition, options)
 	intervalBetweenAutoscaling, e := time.ParseDuration(definition.Spec.IntervalBetweenAutoscaling)
 	if e != nil {
 		log.Printf("intervalBetweenAutoscaling error: %s", e.Error())
-		return
+		return AutoscaleProcessResult{}
+	}
+	result := AutoscaleProcessResult{CloseAutoscaleProcessChannel: make(chan bool)}
+	schedules := parseSchedules(definition.Spec.Schedules)
+	scheduleChannel := make(chan bool)
+	if len(schedules) > 0 {
+		result.ScheduleInterval = clock.SetInterval(func() {
+			select {
+			case scheduleChannel <- true:
+			case <-result.CloseAutoscaleProcessChannel:
+			}
+		}, time.Minute, false)
+	}
+	var activationChannel chan float64
+	var activationThreshold float64
+	var idlePeriod time.Duration
+	if isActivationEnabled(definition.Spec) {
+		activationThreshold, _ = strconv.ParseFloat(definition.Spec.ActivationMetric.ActivationThreshold, 64)
+		idlePeriod, _ = time.ParseDuration(definition.Spec.ActivationMetric.IdlePeriod)
+		activationChannel, result.ActivationScrapeInterval = scrapeActivationMetric(definition.Spec.ActivationMetric, result.CloseAutoscaleProcessChannel, clock)
 	}
 	go func() {
-		var autoscalingBlocked = false
+		var lastActivation = clock.Now()
+		var fallback = newFallbackState()
+		var controlState = ""
+		var rollout = newRolloutGuard(definition.Spec.Rollout)
+		var latestInputs = decisionInputs{}
+		var cooldown = newCooldown(clock, intervalBetweenAutoscaling)
+		var skipped = newSkippedDecisions()
+		var blockAutoscaling = func() {
+			reportCooldown(definitionClient, definition, cooldown.start())
+		}
 		for {
 			select {
+			case <-result.CloseAutoscaleProcessChannel:
+				return
+			case activationValue := <-activationChannel:
+				now := clock.Now()
+				if activationValue > activationThreshold {
+					lastActivation = now
+				}
+				control := annotations.scalingControl(now)
+				if control.IsPaused || control.IsPinned {
+					continue
+				}
+				bounds := currentReplicaBounds(definition.Spec, schedules, now)
+				deploymentScale, err := clients.GetScale(client, definition.Spec.ScaleTarget)
+				if err != nil {
+					log.Printf("Autoscaling error: %s", err.Error())
+					continue
+				}
+				replicas := int(deploymentScale.Spec.Replicas)
+				if replicas == 0 && activationValue > activationThreshold {
+					replicas = int(math.Max(float64(bounds.MinReplicas), math.Min(float64(bounds.MaxReplicas), float64(definition.Spec.ActivationMetric.ActivationReplicas))))
+					log.Printf("Activating %s from zero to %d replicas, activation value: %f", definition.Spec.ScaleTarget.MatchLabel, replicas, activationValue)
+				} else if replicas > 0 && bounds.MinReplicas == 0 && !control.IsScaleDownFrozen && now.Sub(lastActivation) >= idlePeriod {
+					replicas = 0
+					log.Printf("Scaling %s to zero after idle period: %s", definition.Spec.ScaleTarget.MatchLabel, idlePeriod.String())
+				}
+				if replicas != int(deploymentScale.Spec.Replicas) {
+					_, err = scaler.scale(deploymentScale, replicas, newDecision(now, "", "activation metric", nil, PolicyActivation))
+					if err != nil {
+						log.Printf("Autoscaling error: %s", err.Error())
+					}
+				}
+			case <-scheduleChannel:
+				control := annotations.scalingControl(clock.Now())
+				if control.IsPaused || control.IsPinned {
+					continue
+				}
+				bounds := currentReplicaBounds(definition.Spec, schedules, clock.Now())
+				deploymentScale, err := clients.GetScale(client, definition.Spec.ScaleTarget)
+				if err != nil {
+					log.Printf("Autoscaling error: %s", err.Error())
+					continue
+				}
+				replicas := int(math.Max(float64(bounds.MinReplicas), math.Min(float64(bounds.MaxReplicas), float64(deploymentScale.Spec.Replicas))))
+				if control.IsScaleDownFrozen && replicas < int(deploymentScale.Spec.Replicas) {
+					replicas = int(deploymentScale.Spec.Replicas)
+				}
+				if replicas != int(deploymentScale.Spec.Replicas) {
+					log.Printf("Scaling %s from %d to %d replicas to enforce schedule: %s", definition.Spec.ScaleTarget.MatchLabel, deploymentScale.Spec.Replicas, replicas, bounds.ScheduleName)
+					_, err = scaler.scale(deploymentScale, replicas, newDecision(clock.Now(), "", "schedule "+bounds.ScheduleName, nil, PolicySchedule))
+					if err != nil {
+						log.Printf("Autoscaling error: %s", err.Error())
+					}
+				}
 			case ae := <-autoscaleEvaluationChannel:
+				reportMetricStatus(definitionClient, definition, ae)
+				latestInputs.update(ae)
+				if isFallbackEnabled(definition.Spec) && fallback.update(ae, definition.Spec.Fallback.FailureThreshold) {
+					reportFallbackTransition(client, definitionClient, definition, ae.Metric.Name, fallback)
+				}
+				control := annotations.scalingControl(clock.Now())
+				if control.State() != controlState {
+					controlState = control.State()
+					reportScalingControlTransition(client, definitionClient, definition, control)
+				}
+				var policies []string
+				var requested = requestsScaling(ae)
+				var recordSkipped = func(reason string, skipPolicies ...string) {
+					if !requested {
+						return
+					}
+					decision := newDecision(clock.Now(), ae.Metric.Name, reason, latestInputs.list(), append(policies, skipPolicies...)...)
+					if skipped.shouldRecord(decision, cooldown.window()) {
+						recordDecision(definitionClient, definition, decision)
+					}
+				}
+				if control.IsPaused {
+					log.Printf("Autoscaling paused, skipping evaluation of metric: %s", ae.Metric.Name)
+					recordSkipped(control.Message(), PolicyPaused)
+					continue
+				}
+				if isFallbackEnabled(definition.Spec) && fallback.isActive(ae.Metric.Name) {
+					var proceed bool
+					if ae, proceed = applyFallback(ae, definition.Spec.Fallback); !proceed {
+						continue
+					}
+					requested = requestsScaling(ae)
+					policies = append(policies, PolicyFallback)
+				}
+				bounds := currentReplicaBounds(definition.Spec, schedules, clock.Now())
 				deploymentScale, err := clients.GetScale(client, definition.Spec.ScaleTarget)
-				if autoscalingBlocked {
+				if bounds.MinReplicas <= 0 {
+					bounds.MinReplicas = 1
+				}
+				if bounds.IsPinned {
+					log.Printf("Replicas pinned to %d by schedule: %s", bounds.Replicas, bounds.ScheduleName)
+					ae = AutoscaleEvaluation{DesiredReplicas: bounds.Replicas, HasDesiredReplicas: true, Metric: ae.Metric}
+				}
+				if control.IsPinned {
+					log.Print(control.Message())
+					bounds = ReplicaBounds{MinReplicas: control.PinnedReplicas, MaxReplicas: control.PinnedReplicas, Replicas: control.PinnedReplicas, IsPinned: true}
+					ae = AutoscaleEvaluation{DesiredReplicas: control.PinnedReplicas, HasDesiredReplicas: true, Metric: ae.Metric}
+				}
+				restriction := rollout.check(client, definition.Spec.ScaleTarget, clock.Now())
+				if restriction.IsDeferred && !control.IsPinned {
+					log.Printf("Autoscaling deferred until rollout finishes. Metric: %s", ae.Metric.Name)
+					recordSkipped("deferred until rollout of target finishes", PolicyRolloutDeferred)
+					continue
+				}
+				var panicScaling = false
+				if err == nil {
+					ae, panicScaling = applyPanicScaling(ae, int(deploymentScale.Spec.Replicas), bounds)
+					if panicScaling {
+						log.Printf("Panic scaling %s from %d to %d replicas based on metric: %s", definition.Spec.ScaleTarget.MatchLabel, deploymentScale.Spec.Replicas, ae.DesiredReplicas, ae.Metric.Name)
+					}
+				}
+				if cooldown.isActive() && !panicScaling && !control.IsPinned {
 					log.Printf("Autoscaling temporary blocked by intervalBetweenAutoscaling")
+					recordSkipped("blocked by intervalBetweenAutoscaling", PolicyCooldown)
 				} else if err != nil {
 					log.Printf("Autoscaling error: %s", err.Error())
+				} else if deploymentScale.Spec.Replicas == 0 && isActivationEnabled(definition.Spec) && !control.IsPinned {
+					log.Printf("Target %s scaled to zero, waiting for activation. Metric: %s", definition.Spec.ScaleTarget.MatchLabel, ae.Metric.Name)
+					recordSkipped("target scaled to zero, waiting for activation", PolicyWaitingForActivation)
 				} else {
-					if ae.ScaleUp && int(deploymentScale.Spec.Replicas) < definition.Spec.MaxReplicas {
-						log.Printf("Scaling up %s based on metric: %s", definition.Spec.ScaleTarget.MatchLabel, ae.Metric.Name)
-						deploymentScale.Spec.Replicas = int32(math.Min(float64(definition.Spec.MaxReplicas), float64(deploymentScale.Spec.Replicas+int32(definition.Spec.ScalingStep))))
-						deploymentScale, err = clients.ScaleObject(client, definition.Spec.ScaleTarget, deploymentScale)
-						if err != nil {
-							log.Printf("Autoscaling error: %s", err.Error())
-						}
-						clearMetricBufferChannel <- ae.Metric
-						autoscalingBlocked = true
-						util.SetTimeout(func() {
-							autoscalingBlocked = false
-						}, intervalBetweenAutoscaling)
-					} else if ae.ScaleDown && int(deploymentScale.Spec.Replicas) > definition.Spec.MinReplicas {
-						log.Printf("Scaling down %s based on metric: %s", definition.Spec.ScaleTarget.MatchLabel, ae.Metric.Name)
-						deploymentScale.Spec.Replicas = int32(math.Max(float64(definition.Spec.MinReplicas), float64(deploymentScale.Spec.Replicas-int32(definition.Spec.ScalingStep))))
-						deploymentScale, err = clients.ScaleObject(client, definition.Spec.ScaleTarget, deploymentScale)
+					plan := planScaling(ae, int(deploymentScale.Spec.Replicas), bounds, control, restriction, definition.Spec.ScalingStep, panicScaling)
+					if plan.Replicas == int(deploymentScale.Spec.Replicas) {
+						log.Printf("Verified metric: %s, %s", ae.Metric.Name, plan.Reason)
+						recordSkipped(plan.Reason, plan.Policies...)
+					} else {
+						log.Printf("Scaling %s from %d to %d replicas based on metric: %s", definition.Spec.ScaleTarget.MatchLabel, deploymentScale.Spec.Replicas, plan.Replicas, ae.Metric.Name)
+						deploymentScale, err = scaler.scale(deploymentScale, plan.Replicas, newDecision(clock.Now(), ae.Metric.Name, plan.Reason, latestInputs.list(), append(policies, plan.Policies...)...))
 						if err != nil {
 							log.Printf("Autoscaling error: %s", err.Error())
 						}
-						autoscalingBlocked = true
 						clearMetricBufferChannel <- ae.Metric
-						util.SetTimeout(func() {
-							autoscalingBlocked = false
-						}, intervalBetweenAutoscaling)
-					} else if ae.ScaleUp && int(deploymentScale.Spec.Replicas) >= definition.Spec.MaxReplicas {
-						log.Printf("Reached maximum replicas, can't scale up anymore. Metric: %s", ae.Metric.Name)
-					} else if ae.ScaleDown && int(deploymentScale.Spec.Replicas) <= definition.Spec.MinReplicas {
-						log.Printf("Reached minimum replicas, can't scale down anymore. Metric: %s", ae.Metric.Name)
-					} else {
-						log.Printf("Verified metric: %s, no need to scale", ae.Metric.Name)
+						blockAutoscaling()
 					}
 				}
 			}
 		}
 	}()
+	return result
 }
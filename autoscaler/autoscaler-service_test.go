@@ -0,0 +1,69 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"custom-hpa/util"
+	"testing"
+	"time"
+)
+
+func TestEvaluateAutoscalingOnFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := util.NewFakeClock(start)
+	source := metrics.NewMemorySource()
+	metrics.RegisterMetricSource(metrics.MetricTypeMemory, source)
+	source.AddSample("evaluation_load", start, 90)
+	source.AddSample("evaluation_load", start.Add(2*time.Minute+5*time.Second), 50)
+	source.AddSample("evaluation_load", start.Add(4*time.Minute+5*time.Second), 10)
+	metric := scalingv1.AutoscalingDefinitionMetric{Name: "load", MetricType: metrics.MetricTypeMemory, PrometheusQuery: "evaluation_load",
+		ScaleDownValue: "20", ScaleUpValue: "80", ScaleValueType: "double", Algorithm: "mean", NumOfTests: 2,
+		PercentageOfTestConditionFulfillment: 100, ScrapeInterval: "10s", TestInterval: "1m"}
+
+	scrape, err := metrics.MakeScrape(metric, metrics.TargetProviders{Clock: clock})
+	if err != nil {
+		t.Fatalf("MakeScrape() failed: %s", err.Error())
+	}
+	test, err := metrics.MakeTest(metric, scrape)
+	if err != nil {
+		t.Fatalf("MakeTest() failed: %s", err.Error())
+	}
+	evaluation := EvaluateAutoscaling(test, nil, metric, clock)
+	defer func() {
+		test.ScrapeInterval <- true
+		test.TestInterval <- true
+		evaluation.CloseEvaluationProcessChannel <- true
+	}()
+
+	tests := []struct {
+		expectedValue     float64
+		expectedScaleUp   bool
+		expectedScaleDown bool
+	}{
+		{expectedValue: 90},
+		{expectedValue: 90, expectedScaleUp: true},
+		{expectedValue: 50},
+		{expectedValue: 50},
+		{expectedValue: 10},
+		{expectedValue: 10, expectedScaleDown: true},
+	}
+	for i, test := range tests {
+		advanced := make(chan bool)
+		go func() {
+			clock.Advance(time.Minute)
+			close(advanced)
+		}()
+		var ae AutoscaleEvaluation
+		select {
+		case ae = <-evaluation.AutoscaleEvaluation:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("evaluation %d was not made, scrape, test and evaluation process blocked fake clock", i)
+		}
+		<-advanced
+		if !ae.HasMetricValue || ae.MetricValue != test.expectedValue || ae.ScrapeCount != 6 ||
+			ae.ScaleUp != test.expectedScaleUp || ae.ScaleDown != test.expectedScaleDown {
+			t.Errorf("evaluation %d = %+v, expected value %f from 6 scrapes, scale up: %t, scale down: %t",
+				i, ae, test.expectedValue, test.expectedScaleUp, test.expectedScaleDown)
+		}
+	}
+}
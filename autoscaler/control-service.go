@@ -0,0 +1,125 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/pkg/client/clientset/versioned"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PausedAnnotation          = "scaling.com/paused"
+	FreezeScaleDownAnnotation = "scaling.com/freeze-scale-down"
+	PinnedReplicasAnnotation  = "scaling.com/pinned-replicas"
+	PinnedUntilAnnotation     = "scaling.com/pinned-until"
+)
+
+type ScalingControl struct {
+	IsPaused          bool
+	IsScaleDownFrozen bool
+	IsPinned          bool
+	PinnedReplicas    int
+	PinnedUntil       time.Time
+}
+
+func ReadScalingControl(annotations map[string]string, now time.Time) ScalingControl {
+	control := ScalingControl{
+		IsPaused:          strings.ToUpper(annotations[PausedAnnotation]) == "TRUE",
+		IsScaleDownFrozen: strings.ToUpper(annotations[FreezeScaleDownAnnotation]) == "TRUE",
+	}
+	pinnedReplicas, ok := annotations[PinnedReplicasAnnotation]
+	if !ok {
+		return control
+	}
+	replicas, err := strconv.Atoi(pinnedReplicas)
+	if err != nil || replicas < 0 {
+		log.Printf("Annotation %s should be non negative integer, got: %s", PinnedReplicasAnnotation, pinnedReplicas)
+		return control
+	}
+	if pinnedUntil, ok := annotations[PinnedUntilAnnotation]; ok {
+		until, err := time.Parse(time.RFC3339, pinnedUntil)
+		if err != nil {
+			log.Printf("Annotation %s should be RFC3339 timestamp, got: %s", PinnedUntilAnnotation, pinnedUntil)
+			return control
+		}
+		if !now.Before(until) {
+			return control
+		}
+		control.PinnedUntil = until
+	}
+	control.IsPinned = true
+	control.PinnedReplicas = replicas
+	return control
+}
+
+func (c ScalingControl) State() string {
+	if c.IsPaused {
+		return "Paused"
+	}
+	if c.IsPinned {
+		return "Pinned"
+	}
+	if c.IsScaleDownFrozen {
+		return "Frozen"
+	}
+	return "Active"
+}
+
+func (c ScalingControl) Message() string {
+	switch c.State() {
+	case "Paused":
+		return "Autoscaling paused by annotation " + PausedAnnotation
+	case "Pinned":
+		if c.PinnedUntil.IsZero() {
+			return fmt.Sprintf("Replicas pinned to %d by annotation %s", c.PinnedReplicas, PinnedReplicasAnnotation)
+		}
+		return fmt.Sprintf("Replicas pinned to %d until %s by annotation %s", c.PinnedReplicas, c.PinnedUntil.Format(time.RFC3339), PinnedReplicasAnnotation)
+	case "Frozen":
+		return "Scaling down frozen by annotation " + FreezeScaleDownAnnotation
+	}
+	return "Autoscaling active"
+}
+
+// definitionAnnotations holds annotations of definition, which are refreshed by main autoscaling loop from listed definitions,
+// since definition passed to autoscale process is never refreshed
+type definitionAnnotations struct {
+	mutex       sync.RWMutex
+	annotations map[string]string
+}
+
+func newDefinitionAnnotations(annotations map[string]string) *definitionAnnotations {
+	return &definitionAnnotations{annotations: annotations}
+}
+
+func (a *definitionAnnotations) set(annotations map[string]string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.annotations = annotations
+}
+
+func (a *definitionAnnotations) scalingControl(now time.Time) ScalingControl {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return ReadScalingControl(a.annotations, now)
+}
+
+func reportScalingControlTransition(client *kubernetes.Clientset, definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, control ScalingControl) {
+	message := control.Message()
+	log.Print(message)
+	recordEvent(client, definition, corev1.EventTypeNormal, control.State(), message)
+	updateDefinitionStatus(definitionClient, definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+		status.State = control.State()
+		status.Message = message
+		conditionStatus := corev1.ConditionTrue
+		if control.State() != "Active" {
+			conditionStatus = corev1.ConditionFalse
+		}
+		setStatusCondition(status, "ScalingActive", conditionStatus, control.State(), message)
+	})
+}
@@ -0,0 +1,37 @@
+package autoscaler
+
+import (
+	"custom-hpa/util"
+	"time"
+)
+
+// cooldown blocks scaling by metrics for intervalBetweenAutoscaling after successful scaling
+type cooldown struct {
+	clock        util.Clock
+	duration     time.Duration
+	blockedUntil time.Time
+}
+
+// cooldownWindow identifies period between changes of cooldown, it changes when cooldown starts and when it ends
+type cooldownWindow struct {
+	blockedUntil time.Time
+	active       bool
+}
+
+func newCooldown(clock util.Clock, duration time.Duration) *cooldown {
+	return &cooldown{clock: util.ClockOrReal(clock), duration: duration}
+}
+
+// start blocks scaling from now and returns time, when cooldown ends
+func (c *cooldown) start() time.Time {
+	c.blockedUntil = c.clock.Now().Add(c.duration)
+	return c.blockedUntil
+}
+
+func (c *cooldown) isActive() bool {
+	return c.clock.Now().Before(c.blockedUntil)
+}
+
+func (c *cooldown) window() cooldownWindow {
+	return cooldownWindow{blockedUntil: c.blockedUntil, active: c.isActive()}
+}
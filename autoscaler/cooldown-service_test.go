@@ -0,0 +1,33 @@
+package autoscaler
+
+import (
+	"custom-hpa/util"
+	"testing"
+	"time"
+)
+
+func TestCooldown(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := util.NewFakeClock(start)
+	cooldown := newCooldown(clock, 2*time.Minute)
+	if cooldown.isActive() {
+		t.Fatal("cooldown is active before start")
+	}
+
+	if blockedUntil := cooldown.start(); !blockedUntil.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("cooldown blocked until %s, expected %s", blockedUntil, start.Add(2*time.Minute))
+	}
+	clock.Advance(2*time.Minute - time.Second)
+	if !cooldown.isActive() {
+		t.Error("cooldown is not active before interval passed")
+	}
+	clock.Advance(time.Second)
+	if cooldown.isActive() {
+		t.Error("cooldown is active after interval passed")
+	}
+
+	cooldown.start()
+	if !cooldown.isActive() {
+		t.Error("restarted cooldown is not active")
+	}
+}
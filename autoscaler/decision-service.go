@@ -0,0 +1,131 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/pkg/client/clientset/versioned"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDecisionHistoryLimit = 10
+
+	PolicyScalingStep          = "ScalingStep"
+	PolicyDesiredReplicas      = "DesiredReplicas"
+	PolicyReplicaDelta         = "ReplicaDelta"
+	PolicyPanic                = "Panic"
+	PolicyPinned               = "Pinned"
+	PolicySchedule             = "Schedule"
+	PolicyActivation           = "Activation"
+	PolicyReplicaBounds        = "ReplicaBounds"
+	PolicyScaleDownFrozen      = "ScaleDownFrozen"
+	PolicyRolloutCapped        = "RolloutCapped"
+	PolicyRolloutDeferred      = "RolloutDeferred"
+	PolicyPaused               = "Paused"
+	PolicyFallback             = "Fallback"
+	PolicyCooldown             = "Cooldown"
+	PolicyWaitingForActivation = "WaitingForActivation"
+)
+
+// decisionInputs holds latest evaluation input of every metric, so that decision records state of all metrics and not only of the deciding one.
+type decisionInputs map[string]scalingv1.AutoscalingDefinitionDecisionInput
+
+func (inputs decisionInputs) update(ae AutoscaleEvaluation) {
+	if !ae.HasMetricValue {
+		return
+	}
+	inputs[ae.Metric.Name] = newDecisionInput(ae)
+}
+
+func (inputs decisionInputs) list() []scalingv1.AutoscalingDefinitionDecisionInput {
+	var list []scalingv1.AutoscalingDefinitionDecisionInput
+	for _, input := range inputs {
+		list = append(list, input)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Metric < list[j].Metric
+	})
+	return list
+}
+
+func newDecisionInput(ae AutoscaleEvaluation) scalingv1.AutoscalingDefinitionDecisionInput {
+	input := scalingv1.AutoscalingDefinitionDecisionInput{
+		Metric:                ae.Metric.Name,
+		ScrapeCount:           ae.ScrapeCount,
+		Value:                 strconv.FormatFloat(ae.MetricValue, 'f', -1, 64),
+		NumOfTests:            ae.NumOfTests,
+		LowerBoundTestsPassed: ae.LowerBoundTests,
+		UpperBoundTestsPassed: ae.UpperBoundTests,
+	}
+	if ae.HasPredictedValue {
+		input.PredictedValue = strconv.FormatFloat(ae.PredictedValue, 'f', -1, 64)
+	}
+	for _, exogenousRegressor := range ae.ExogenousRegressors {
+		input.ExogenousRegressors = append(input.ExogenousRegressors, scalingv1.AutoscalingDefinitionDecisionExogenousInput{
+			Name:  exogenousRegressor.Name,
+			Value: strconv.FormatFloat(exogenousRegressor.Value, 'f', -1, 64),
+		})
+	}
+	return input
+}
+
+// requestsScaling returns true when evaluation could have changed replicas, only such evaluations are recorded in decision history.
+func requestsScaling(ae AutoscaleEvaluation) bool {
+	return ae.ScaleUp || ae.ScaleDown || ae.HasDesiredReplicas || ae.ReplicaDelta != 0 || ae.Panic
+}
+
+func newDecision(now time.Time, metricName string, reason string, inputs []scalingv1.AutoscalingDefinitionDecisionInput, policies ...string) scalingv1.AutoscalingDefinitionDecision {
+	return scalingv1.AutoscalingDefinitionDecision{
+		Time:     meta_v1.NewTime(now),
+		Metric:   metricName,
+		Reason:   reason,
+		Policies: policies,
+		Inputs:   inputs,
+	}
+}
+
+// skippedDecisions remembers skipped decisions recorded within current cooldown window, so that evaluations repeated every test interval
+// do not evict scaling decisions from limited history.
+type skippedDecisions struct {
+	window   cooldownWindow
+	recorded map[string]bool
+}
+
+func newSkippedDecisions() *skippedDecisions {
+	return &skippedDecisions{recorded: map[string]bool{}}
+}
+
+// shouldRecord returns true only for the first decision with given metric, reason and policies within window
+func (s *skippedDecisions) shouldRecord(decision scalingv1.AutoscalingDefinitionDecision, window cooldownWindow) bool {
+	if window != s.window {
+		s.window = window
+		s.recorded = map[string]bool{}
+	}
+	key := decision.Metric + "/" + decision.Reason + "/" + strings.Join(decision.Policies, ",")
+	if s.recorded[key] {
+		return false
+	}
+	s.recorded[key] = true
+	return true
+}
+
+// appendDecision appends decision to history and drops the oldest decisions over limit.
+func appendDecision(status *scalingv1.AutoscalingDefinitionStatus, decision scalingv1.AutoscalingDefinitionDecision, limit int) {
+	if limit <= 0 {
+		limit = DefaultDecisionHistoryLimit
+	}
+	status.Decisions = append(status.Decisions, decision)
+	if len(status.Decisions) > limit {
+		status.Decisions = status.Decisions[len(status.Decisions)-limit:]
+	}
+}
+
+// recordDecision stores decision, which did not change replicas, in history of definition.
+func recordDecision(definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, decision scalingv1.AutoscalingDefinitionDecision) {
+	updateDefinitionStatus(definitionClient, definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+		appendDecision(status, decision, definition.Spec.DecisionHistoryLimit)
+	})
+}
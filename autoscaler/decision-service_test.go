@@ -0,0 +1,66 @@
+package autoscaler
+
+import (
+	"custom-hpa/util"
+	"testing"
+	"time"
+)
+
+func TestNewDecisionUsesGivenTime(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	decision := newDecision(now, "cpu", "scale up", nil, PolicyScalingStep)
+	if !decision.Time.Time.Equal(now) {
+		t.Errorf("newDecision() time = %s, expected %s", decision.Time.Time, now)
+	}
+}
+
+func TestSkippedDecisions(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := util.NewFakeClock(start)
+	cooldown := newCooldown(clock, 2*time.Minute)
+	skipped := newSkippedDecisions()
+	blocked := newDecision(clock.Now(), "cpu", "blocked by intervalBetweenAutoscaling", nil, PolicyCooldown)
+	paused := newDecision(clock.Now(), "cpu", "paused", nil, PolicyPaused)
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		start    bool
+		decision string
+		expected bool
+	}{
+		{name: "first skip before cooldown", decision: "paused", expected: true},
+		{name: "repeated skip before cooldown", advance: time.Minute, decision: "paused", expected: false},
+		{name: "first skip in cooldown", start: true, decision: "blocked", expected: true},
+		{name: "repeated skip in cooldown", advance: 30 * time.Second, decision: "blocked", expected: false},
+		{name: "other skip in cooldown", decision: "paused", expected: true},
+		{name: "repeated other skip in cooldown", advance: 30 * time.Second, decision: "paused", expected: false},
+		{name: "skip after cooldown ended", advance: time.Minute, decision: "paused", expected: true},
+		{name: "skip in next cooldown", start: true, decision: "blocked", expected: true},
+	}
+	for _, test := range tests {
+		clock.Advance(test.advance)
+		if test.start {
+			cooldown.start()
+		}
+		decision := paused
+		if test.decision == "blocked" {
+			decision = blocked
+		}
+		if result := skipped.shouldRecord(decision, cooldown.window()); result != test.expected {
+			t.Errorf("%s: shouldRecord() = %t, expected %t", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestSkippedDecisionsDistinguishMetrics(t *testing.T) {
+	skipped := newSkippedDecisions()
+	window := cooldownWindow{}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !skipped.shouldRecord(newDecision(now, "cpu", "paused", nil, PolicyPaused), window) {
+		t.Error("shouldRecord() of cpu metric = false, expected true")
+	}
+	if !skipped.shouldRecord(newDecision(now, "memory", "paused", nil, PolicyPaused), window) {
+		t.Error("shouldRecord() of memory metric = false, expected true")
+	}
+}
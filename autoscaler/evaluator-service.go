@@ -0,0 +1,134 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Evaluator turns test results of one metric into autoscale evaluations. It owns no timers and channels,
+// so that the same evaluation is driven by evaluation process and by simulator.
+type Evaluator interface {
+	Evaluate(testResult metrics.TestResult, exogenousRegressor ExogenousRegressorScrapeResult, now time.Time) AutoscaleEvaluation
+	// Clear drops test history after scaling
+	Clear()
+}
+
+// EvaluatorDefinition creates evaluator of algorithm. Exogenous regressor is collected for algorithm, which requires it,
+// and for algorithm, which accepts it, when exogenous regressors of metric are set. Algorithm, which reads metric by its own
+// pipeline instead of aggregated scrapes, sets NewPipeline instead of NewEvaluator, so that its metrics cannot be simulated.
+type EvaluatorDefinition struct {
+	NewEvaluator               func(metric scalingv1.AutoscalingDefinitionMetric) Evaluator
+	NewPipeline                func(metric scalingv1.AutoscalingDefinitionMetric, providers metrics.TargetProviders) (MetricPipeline, error)
+	RequiresExogenousRegressor bool
+	AcceptsExogenousRegressor  bool
+}
+
+// MetricPipeline holds intervals and evaluation process, which read and evaluate one metric
+type MetricPipeline struct {
+	ScrapeInterval                  chan bool
+	TestInterval                    chan bool
+	TestResultsChannel              chan metrics.TestResult
+	ExogenousScrapeInterval         chan bool
+	ExogenousRegressorResultChannel chan ExogenousRegressorScrapeResult
+	Evaluation                      AutoscaleEvaluationResult
+}
+
+var reactiveEvaluatorDefinition = EvaluatorDefinition{
+	NewEvaluator: func(metric scalingv1.AutoscalingDefinitionMetric) Evaluator {
+		return newReactiveEvaluator(metric)
+	},
+}
+
+var evaluators = map[string]EvaluatorDefinition{
+	"arimax": {
+		NewEvaluator: func(metric scalingv1.AutoscalingDefinitionMetric) Evaluator {
+			return newPredictiveEvaluator(metric)
+		},
+		RequiresExogenousRegressor: true,
+	},
+	"pid": {
+		NewEvaluator: func(metric scalingv1.AutoscalingDefinitionMetric) Evaluator {
+			return newPidEvaluator(metric)
+		},
+	},
+	metrics.AlgorithmExternal: {
+		NewEvaluator: func(metric scalingv1.AutoscalingDefinitionMetric) Evaluator {
+			return newExternalEvaluator(metric)
+		},
+		AcceptsExogenousRegressor: true,
+	},
+	metrics.AlgorithmQueue: {
+		NewPipeline: startQueuePipeline,
+	},
+}
+var evaluatorsMutex sync.RWMutex
+
+// RegisterEvaluator sets evaluator of algorithm, algorithms without evaluator are evaluated reactively by their bound tests.
+// New algorithm is added in single file by init function, which registers its aggregator by metrics.RegisterAggregator
+// and its evaluator.
+func RegisterEvaluator(algorithm string, definition EvaluatorDefinition) {
+	evaluatorsMutex.Lock()
+	defer evaluatorsMutex.Unlock()
+	evaluators[strings.ToLower(algorithm)] = definition
+}
+
+func getEvaluatorDefinition(algorithm string) EvaluatorDefinition {
+	evaluatorsMutex.RLock()
+	defer evaluatorsMutex.RUnlock()
+	if definition, ok := evaluators[strings.ToLower(algorithm)]; ok {
+		return definition
+	}
+	return reactiveEvaluatorDefinition
+}
+
+// StartMetricPipeline starts reading and evaluation of metric by pipeline of its algorithm
+func StartMetricPipeline(metric scalingv1.AutoscalingDefinitionMetric, providers metrics.TargetProviders) (MetricPipeline, error) {
+	if definition := getEvaluatorDefinition(metric.Algorithm); definition.NewPipeline != nil {
+		return definition.NewPipeline(metric, providers)
+	}
+	return startScrapePipeline(metric, providers)
+}
+
+// startScrapePipeline aggregates scrapes of every test interval and evaluates test results by evaluator of algorithm
+func startScrapePipeline(metric scalingv1.AutoscalingDefinitionMetric, providers metrics.TargetProviders) (MetricPipeline, error) {
+	scrapeResultChannel, err := metrics.MakeScrape(metric, providers)
+	if err != nil {
+		return MetricPipeline{}, err
+	}
+	testResultsChannel, err := metrics.MakeTest(metric, scrapeResultChannel)
+	if err != nil {
+		return MetricPipeline{}, err
+	}
+	exogenousRegressorResultChannel := ExogenousRegressorResultChannel{}
+	if RequiresExogenousRegressor(metric) {
+		exogenousRegressorResultChannel, err = CollectExogenousMetrics(metric, providers.Clock)
+		if err != nil {
+			return MetricPipeline{}, err
+		}
+	}
+	return MetricPipeline{
+		ScrapeInterval:                  testResultsChannel.ScrapeInterval,
+		TestInterval:                    testResultsChannel.TestInterval,
+		TestResultsChannel:              testResultsChannel.TestResultsChannel,
+		ExogenousScrapeInterval:         exogenousRegressorResultChannel.scrapeInterval,
+		ExogenousRegressorResultChannel: exogenousRegressorResultChannel.exogenousRegressorResultChannel,
+		Evaluation:                      EvaluateAutoscaling(testResultsChannel, exogenousRegressorResultChannel.exogenousRegressorResultChannel, metric, providers.Clock),
+	}, nil
+}
+
+// IsSimulated returns true when metric is evaluated by evaluator, which is driven by simulator
+func IsSimulated(metric scalingv1.AutoscalingDefinitionMetric) bool {
+	return getEvaluatorDefinition(metric.Algorithm).NewEvaluator != nil
+}
+
+func NewEvaluator(metric scalingv1.AutoscalingDefinitionMetric) Evaluator {
+	return getEvaluatorDefinition(metric.Algorithm).NewEvaluator(metric)
+}
+
+func RequiresExogenousRegressor(metric scalingv1.AutoscalingDefinitionMetric) bool {
+	definition := getEvaluatorDefinition(metric.Algorithm)
+	return definition.RequiresExogenousRegressor || (definition.AcceptsExogenousRegressor && len(metrics.ExogenousRegressors(metric)) > 0)
+}
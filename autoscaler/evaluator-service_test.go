@@ -0,0 +1,80 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type constantEvaluator struct {
+	desiredReplicas int
+}
+
+func (e *constantEvaluator) Evaluate(testResult metrics.TestResult, exogenousRegressor ExogenousRegressorScrapeResult, now time.Time) AutoscaleEvaluation {
+	return AutoscaleEvaluation{DesiredReplicas: e.desiredReplicas, HasDesiredReplicas: true}
+}
+
+func (e *constantEvaluator) Clear() {
+}
+
+func TestNewEvaluator(t *testing.T) {
+	RegisterEvaluator("Constant", EvaluatorDefinition{
+		NewEvaluator: func(metric scalingv1.AutoscalingDefinitionMetric) Evaluator {
+			return &constantEvaluator{desiredReplicas: 3}
+		},
+	})
+	tests := []struct {
+		algorithm                  string
+		expectedType               string
+		requiresExogenousRegressor bool
+	}{
+		{algorithm: "default", expectedType: "*autoscaler.reactiveEvaluator"},
+		{algorithm: "median", expectedType: "*autoscaler.reactiveEvaluator"},
+		{algorithm: "ARIMAX", expectedType: "*autoscaler.predictiveEvaluator", requiresExogenousRegressor: true},
+		{algorithm: "pid", expectedType: "*autoscaler.pidEvaluator"},
+		{algorithm: "constant", expectedType: "*autoscaler.constantEvaluator"},
+	}
+	for _, test := range tests {
+		t.Run(test.algorithm, func(t *testing.T) {
+			metric := scalingv1.AutoscalingDefinitionMetric{Name: "cpu", Algorithm: test.algorithm, NumOfTests: 3}
+			evaluator := NewEvaluator(metric)
+			if evaluatorType := fmt.Sprintf("%T", evaluator); evaluatorType != test.expectedType {
+				t.Errorf("NewEvaluator() = %s, expected %s", evaluatorType, test.expectedType)
+			}
+			if requires := RequiresExogenousRegressor(metric); requires != test.requiresExogenousRegressor {
+				t.Errorf("RequiresExogenousRegressor() = %t, expected %t", requires, test.requiresExogenousRegressor)
+			}
+		})
+	}
+}
+
+func TestStartMetricPipeline(t *testing.T) {
+	evaluation := AutoscaleEvaluationResult{AutoscaleEvaluation: make(chan AutoscaleEvaluation)}
+	RegisterEvaluator("Stream", EvaluatorDefinition{
+		NewPipeline: func(metric scalingv1.AutoscalingDefinitionMetric, providers metrics.TargetProviders) (MetricPipeline, error) {
+			return MetricPipeline{Evaluation: evaluation}, nil
+		},
+	})
+	pipeline, err := StartMetricPipeline(scalingv1.AutoscalingDefinitionMetric{Name: "events", Algorithm: "stream"}, metrics.TargetProviders{})
+	if err != nil || pipeline.Evaluation.AutoscaleEvaluation != evaluation.AutoscaleEvaluation {
+		t.Errorf("StartMetricPipeline() = %+v, %v, expected pipeline of registered algorithm", pipeline, err)
+	}
+
+	tests := []struct {
+		algorithm string
+		simulated bool
+	}{
+		{algorithm: "default", simulated: true},
+		{algorithm: "pid", simulated: true},
+		{algorithm: "external", simulated: true},
+		{algorithm: "Queue", simulated: false},
+		{algorithm: "stream", simulated: false},
+	}
+	for _, test := range tests {
+		if simulated := IsSimulated(scalingv1.AutoscalingDefinitionMetric{Algorithm: test.algorithm}); simulated != test.simulated {
+			t.Errorf("IsSimulated() of %s algorithm = %t, expected %t", test.algorithm, simulated, test.simulated)
+		}
+	}
+}
@@ -1,10 +1,11 @@
 package autoscaler
 
 import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/metrics"
-	"custom-hpa/model"
 	"custom-hpa/util"
 	"errors"
+	"fmt"
 	model2 "github.com/prometheus/common/model"
 	"log"
 	"math"
@@ -19,14 +20,16 @@ type ScrapedMetricItem struct {
 	Value         []model2.Value
 }
 
-type ScrapeResultMap struct {
-	Name        string
-	ScrapedList []ScrapedMetricItem
+type ExogenousRegressorValue struct {
+	Name  string
+	Value float64
 }
 
+// ExogenousRegressorScrapeResult holds values of exogenous regressors of metric for one test interval ordered as regressors
+// returned by metrics.ExogenousRegressors, result is not valid until values of all regressors are available after their lag
 type ExogenousRegressorScrapeResult struct {
 	Name    string
-	Value   float64
+	Values  []ExogenousRegressorValue
 	IsValid bool
 }
 
@@ -35,7 +38,17 @@ type ExogenousRegressorResultChannel struct {
 	scrapeInterval                  chan bool
 }
 
-func CollectExogenousMetrics(metric model.AutoscalingDefinitionMetric) (ExogenousRegressorResultChannel, error) {
+// exogenousRegressorCollector aggregates scrapes of exogenous regressors of one test interval and delays aggregated values by lag of regressor
+type exogenousRegressorCollector struct {
+	metric       scalingv1.AutoscalingDefinitionMetric
+	regressors   []scalingv1.AutoscalingDefinitionExogenousRegressor
+	maxValues    []float64
+	values       [][]float64
+	validScrapes []int
+	lagged       [][]float64
+}
+
+func CollectExogenousMetrics(metric scalingv1.AutoscalingDefinitionMetric, clock util.Clock) (ExogenousRegressorResultChannel, error) {
 	scrapeDuration, err := time.ParseDuration(metric.ScrapeInterval)
 	if err != nil {
 		return ExogenousRegressorResultChannel{}, err
@@ -44,74 +57,120 @@ func CollectExogenousMetrics(metric model.AutoscalingDefinitionMetric) (Exogenou
 	if err != nil {
 		return ExogenousRegressorResultChannel{}, err
 	}
-	exogenousRegressorResultChannel, scrapeInterval := ScrapeExogenousMetrics(metric, testDuration, scrapeDuration)
+	exogenousRegressorResultChannel, scrapeInterval, err := ScrapeExogenousMetrics(metric, testDuration, scrapeDuration, util.ClockOrReal(clock))
+	if err != nil {
+		return ExogenousRegressorResultChannel{}, err
+	}
 	return ExogenousRegressorResultChannel{
 		exogenousRegressorResultChannel: exogenousRegressorResultChannel,
 		scrapeInterval:                  scrapeInterval,
 	}, nil
 }
 
-func ScrapeExogenousMetrics(metric model.AutoscalingDefinitionMetric, testDuration time.Duration, scrapeDuration time.Duration) (exogenousRegressorResultChannel chan ExogenousRegressorScrapeResult, scrapeInterval chan bool) {
-	maxNumOfScrapes := int64(testDuration) / int64(scrapeDuration)
-	var scrapesCounter int64 = 0
-	scrapedMetrics := ScrapeResultMap{
-		Name:        metric.Name,
-		ScrapedList: make([]ScrapedMetricItem, 0),
+// ScrapeExogenousMetrics scrapes all exogenous regressors of metric every scrape interval and sends their values at the end of test interval
+func ScrapeExogenousMetrics(metric scalingv1.AutoscalingDefinitionMetric, testDuration time.Duration, scrapeDuration time.Duration, clock util.Clock) (exogenousRegressorResultChannel chan ExogenousRegressorScrapeResult, scrapeInterval chan bool, err error) {
+	collector, err := newExogenousRegressorCollector(metric)
+	if err != nil {
+		return nil, nil, err
 	}
+	maxNumOfScrapes := int(int64(testDuration) / int64(scrapeDuration))
+	var scrapesCounter = 0
 	exogenousRegressorResultChannel = make(chan ExogenousRegressorScrapeResult, 2)
 
-	scrapeInterval = util.SetInterval(func() {
-		result, err := scrapeMetric(metric)
-		scrapesCounter++
-		if err == nil && result.IsMetricValid {
-			scrapedMetrics.ScrapedList = append(scrapedMetrics.ScrapedList, result)
+	scrapeInterval = clock.SetInterval(func() {
+		now := clock.Now()
+		for i, regressor := range collector.regressors {
+			if values, err := scrapeExogenousRegressor(metric, regressor, now); err == nil {
+				collector.add(i, values)
+			}
 		}
+		scrapesCounter++
 		if scrapesCounter >= maxNumOfScrapes {
 			scrapesCounter = 0
-			if int((maxNumOfScrapes+1)/2) > len(scrapedMetrics.ScrapedList) {
-				exogenousRegressorMaxValue, err := strconv.ParseFloat(metric.ExogenousRegressorMaxValue, 64)
-				if err != nil {
-					log.Printf("Float conversion error - autoregressionCoefficients: %s", err.Error())
-					panic(err)
-				}
-				exogenousRegressorResultChannel <- ExogenousRegressorScrapeResult{
-					Name:    metric.Name,
-					Value:   exogenousRegressorMaxValue,
-					IsValid: true,
-				}
-			}
-			scrapeValuesRobustMean := calculateScrapeValuesRobustMean(scrapedMetrics.ScrapedList, metric)
-			exogenousRegressorResultChannel <- ExogenousRegressorScrapeResult{
-				Name:    metric.Name,
-				Value:   scrapeValuesRobustMean,
-				IsValid: true,
-			}
-			scrapedMetrics.ScrapedList = nil
+			exogenousRegressorResultChannel <- collector.result(maxNumOfScrapes)
 		}
 	}, scrapeDuration, false)
 
 	return
 }
 
-func scrapeMetric(metric model.AutoscalingDefinitionMetric) (ScrapedMetricItem, error) {
-	var result ScrapedMetricItem
-	value, err := metrics.ReadMetric(metric.PrometheusPath, metric.ExogenousRegressorQuery)
+func scrapeExogenousRegressor(metric scalingv1.AutoscalingDefinitionMetric, regressor scalingv1.AutoscalingDefinitionExogenousRegressor, at time.Time) ([]float64, error) {
+	value, err := metrics.ReadMetric(regressor.Source, metrics.MetricSourceSpec{Address: regressor.PrometheusPath, Query: regressor.Query, Time: at})
 	if err != nil {
-		log.Printf("Error: %s", err.Error())
-		result = ScrapedMetricItem{IsMetricValid: false, MetricName: metric.Name}
-		return result, err
+		log.Printf("Error of exogenous regressor %s: %s", regressor.Name, err.Error())
+		return nil, err
 	}
-	result, err = parseMetricValue(value, metric)
+	result, err := parseMetricValue(value, metric)
 	if err != nil {
-		log.Printf("Error: %s", err.Error())
-		result = ScrapedMetricItem{IsMetricValid: false, MetricName: metric.Name}
-		return result, err
+		log.Printf("Error of exogenous regressor %s: %s", regressor.Name, err.Error())
+		return nil, err
+	}
+	var values []float64
+	for _, value := range result.Value {
+		if scalar, ok := value.(*model2.Scalar); ok {
+			values = append(values, float64(scalar.Value))
+		}
+	}
+	return values, nil
+}
+
+func newExogenousRegressorCollector(metric scalingv1.AutoscalingDefinitionMetric) (*exogenousRegressorCollector, error) {
+	regressors := metrics.ExogenousRegressors(metric)
+	collector := &exogenousRegressorCollector{
+		metric:       metric,
+		regressors:   regressors,
+		maxValues:    make([]float64, len(regressors)),
+		values:       make([][]float64, len(regressors)),
+		validScrapes: make([]int, len(regressors)),
+		lagged:       make([][]float64, len(regressors)),
+	}
+	for i, regressor := range regressors {
+		maxValue, err := strconv.ParseFloat(regressor.MaxValue, 64)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("maxValue of exogenous regressor %s of metric %s is invalid: %s", regressor.Name, metric.Name, err.Error()))
+		}
+		collector.maxValues[i] = maxValue
+	}
+	return collector, nil
+}
+
+// add records values of one scrape of regressor, scrape without values is not valid
+func (c *exogenousRegressorCollector) add(regressor int, values []float64) {
+	if len(values) <= 0 {
+		return
+	}
+	c.values[regressor] = append(c.values[regressor], values...)
+	c.validScrapes[regressor]++
+}
+
+// result aggregates values of test interval and clears them. Value of regressor, which has valid less than half of expected scrapes,
+// is replaced by its max value.
+func (c *exogenousRegressorCollector) result(expectedScrapes int) ExogenousRegressorScrapeResult {
+	result := ExogenousRegressorScrapeResult{Name: c.metric.Name, IsValid: len(c.regressors) > 0}
+	for i, regressor := range c.regressors {
+		value := c.maxValues[i]
+		if c.validScrapes[i] > 0 && c.validScrapes[i] >= (expectedScrapes+1)/2 {
+			value = math.Min(aggregateExogenousValues(c.values[i], regressor.Aggregation, c.metric.TrimmedPercentage), c.maxValues[i])
+		}
+		c.values[i], c.validScrapes[i] = nil, 0
+
+		c.lagged[i] = append(c.lagged[i], value)
+		if len(c.lagged[i]) > regressor.Lag+1 {
+			c.lagged[i] = c.lagged[i][1:]
+		}
+		if len(c.lagged[i]) <= regressor.Lag {
+			result.IsValid = false
+			continue
+		}
+		result.Values = append(result.Values, ExogenousRegressorValue{Name: regressor.Name, Value: c.lagged[i][0]})
 	}
-	result.IsMetricValid = true
-	return result, nil
+	if !result.IsValid {
+		result.Values = nil
+	}
+	return result
 }
 
-func parseMetricValue(value model2.Value, metric model.AutoscalingDefinitionMetric) (ScrapedMetricItem, error) {
+func parseMetricValue(value model2.Value, metric scalingv1.AutoscalingDefinitionMetric) (ScrapedMetricItem, error) {
 	var result = ScrapedMetricItem{}
 	var err error
 	switch value.Type() {
@@ -183,75 +242,45 @@ func parseMetricValue(value model2.Value, metric model.AutoscalingDefinitionMetr
 	return result, err
 }
 
-func calculateScrapeValuesRobustMean(scrapeList []ScrapedMetricItem, metric model.AutoscalingDefinitionMetric) float64 {
-	if scrapeList == nil || len(scrapeList) <= 0 {
-		log.Printf("No scrapes found.")
-		return 0
-	}
-	result := calculateRobustMean(scrapeList, metric.TrimmedPercentage)
-
-	exogenousRegressorMaxValue, err := strconv.ParseFloat(metric.ExogenousRegressorMaxValue, 64)
-	if err != nil {
-		log.Printf("Float conversion error - autoregressionCoefficients: %s", err.Error())
-		panic(err)
-	}
-
-	if result > exogenousRegressorMaxValue {
-		result = exogenousRegressorMaxValue
+func aggregateExogenousValues(values []float64, aggregation string, trimmedPercentage int) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	switch aggregation {
+	case metrics.ExogenousAggregationLast:
+		return values[len(values)-1]
+	case metrics.ExogenousAggregationMax:
+		return sorted[n-1]
+	case metrics.ExogenousAggregationMin:
+		return sorted[0]
+	case metrics.ExogenousAggregationMean:
+		return calculateMean(sorted)
+	case metrics.ExogenousAggregationMedian:
+		return calculateMedian(sorted)
 	}
-	return result
-}
-
-func calculateRobustMean(scrapeList []ScrapedMetricItem, trimmedPercentage int) float64 {
-	flatScrapeList := flattenScrapeList(scrapeList)
-	sort.Slice(flatScrapeList, func(i, j int) bool {
-		a, aok := flatScrapeList[i].Value[0].(*model2.Scalar)
-		b, bok := flatScrapeList[j].Value[0].(*model2.Scalar)
-		if aok && bok {
-			return float64(a.Value) > float64(b.Value)
-		}
-		return false
-	})
-
-	percent := float64(trimmedPercentage)
-	n := len(flatScrapeList)
-	k := int(math.Round(float64(n) * (percent / 100.0) / 2.0))
-	if k == 0 {
-		return calculateMean(flatScrapeList)
+	k := int(math.Round(float64(n) * (float64(trimmedPercentage) / 100.0) / 2.0))
+	if n-2*k <= 0 {
+		return calculateMedian(sorted)
 	}
-	flatScrapeList = flatScrapeList[k:]
-	flatScrapeList = flatScrapeList[:len(flatScrapeList)-k-1]
-	return calculateMean(flatScrapeList)
-
+	return calculateMean(sorted[k : n-k])
 }
 
-func calculateMean(scrapeList []ScrapedMetricItem) float64 {
-	if scrapeList == nil || len(scrapeList) <= 0 {
+func calculateMean(values []float64) float64 {
+	if len(values) <= 0 {
 		return 0
 	}
 	var sum = 0.0
-	for _, scrape := range scrapeList {
-		switch scrape.Value[0].Type() {
-		case model2.ValScalar:
-			var value, ok = scrape.Value[0].(*model2.Scalar)
-			if ok {
-				sum += float64(value.Value)
-			}
-		}
+	for _, value := range values {
+		sum += value
 	}
-	return sum / float64(len(scrapeList))
+	return sum / float64(len(values))
 }
 
-func flattenScrapeList(scrapeList []ScrapedMetricItem) []ScrapedMetricItem {
-	var result []ScrapedMetricItem
-	for _, scrape := range scrapeList {
-		for _, value := range scrape.Value {
-			result = append(result, ScrapedMetricItem{
-				MetricName:    scrape.MetricName,
-				IsMetricValid: scrape.IsMetricValid,
-				Value:         []model2.Value{value}},
-			)
-		}
+// calculateMedian of sorted values
+func calculateMedian(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
 	}
-	return result
+	return sorted[n/2]
 }
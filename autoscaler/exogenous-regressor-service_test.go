@@ -0,0 +1,120 @@
+package autoscaler
+
+import (
+	"context"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	model2 "github.com/prometheus/common/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExogenousRegressorCollector(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		Name:              "cpu",
+		TrimmedPercentage: 50,
+		ExogenousRegressors: []scalingv1.AutoscalingDefinitionExogenousRegressor{
+			{Name: "requests", Query: "requests", MaxValue: "100"},
+			{Name: "payload", Query: "payload", MaxValue: "10", Aggregation: "max"},
+			{Name: "misses", Query: "misses", MaxValue: "1", Aggregation: "last", Lag: 1},
+		},
+	}
+	collector, err := newExogenousRegressorCollector(metric)
+	if err != nil {
+		t.Fatalf("newExogenousRegressorCollector() error: %s", err.Error())
+	}
+	tests := []struct {
+		name     string
+		scrapes  [][][]float64
+		expected ExogenousRegressorScrapeResult
+	}{
+		{
+			name:     "lagged regressor is not available",
+			scrapes:  [][][]float64{{{1}, {2}, {0.5}}, {{50}, {4}, {0.25}}, {{3}, {6}, {0.75}}, {{4}, {8}, {0.5}}},
+			expected: ExogenousRegressorScrapeResult{Name: "cpu"},
+		},
+		{
+			// trimmed mean of 1..8 drops 2 values from both ends, max value caps payload, misses is value of previous interval
+			name:    "aggregated, capped and lagged values",
+			scrapes: [][][]float64{{{1, 2}, {20}, {0.1}}, {{3, 4}, {4}, nil}, {{5, 6}, {6}, {0.2}}, {{7, 8}, {8}, nil}},
+			expected: ExogenousRegressorScrapeResult{Name: "cpu", IsValid: true, Values: []ExogenousRegressorValue{
+				{Name: "requests", Value: 4.5}, {Name: "payload", Value: 10}, {Name: "misses", Value: 0.5},
+			}},
+		},
+		{
+			name:    "too few valid scrapes are replaced by max value",
+			scrapes: [][][]float64{{nil, {1}, {0.3}}, {nil, {2}, nil}, {{5}, {3}, nil}, {nil, {4}, nil}},
+			expected: ExogenousRegressorScrapeResult{Name: "cpu", IsValid: true, Values: []ExogenousRegressorValue{
+				{Name: "requests", Value: 100}, {Name: "payload", Value: 4}, {Name: "misses", Value: 0.2},
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, scrape := range test.scrapes {
+				for i, values := range scrape {
+					collector.add(i, values)
+				}
+			}
+			if result := collector.result(len(test.scrapes)); !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("result() = %+v, expected %+v", result, test.expected)
+			}
+		})
+	}
+}
+
+func TestAggregateExogenousValues(t *testing.T) {
+	values := []float64{4, 1, 3, 2, 10}
+	tests := []struct {
+		aggregation       string
+		trimmedPercentage int
+		expected          float64
+	}{
+		{aggregation: "trimmedmean", trimmedPercentage: 0, expected: 4},
+		{aggregation: "trimmedmean", trimmedPercentage: 40, expected: 3},
+		{aggregation: "trimmedmean", trimmedPercentage: 100, expected: 3},
+		{aggregation: "mean", expected: 4},
+		{aggregation: "median", expected: 3},
+		{aggregation: "max", expected: 10},
+		{aggregation: "min", expected: 1},
+		{aggregation: "last", expected: 10},
+	}
+	for _, test := range tests {
+		if value := aggregateExogenousValues(values, test.aggregation, test.trimmedPercentage); value != test.expected {
+			t.Errorf("aggregateExogenousValues(%s, %d) = %f, expected %f", test.aggregation, test.trimmedPercentage, value, test.expected)
+		}
+	}
+}
+
+type addressRecordingSource struct {
+	addresses []string
+}
+
+func (s *addressRecordingSource) Query(ctx context.Context, spec metrics.MetricSourceSpec) (model2.Value, error) {
+	s.addresses = append(s.addresses, spec.Address)
+	return &model2.Scalar{Value: 1}, nil
+}
+
+func TestScrapeExogenousRegressorAddress(t *testing.T) {
+	source := &addressRecordingSource{}
+	metrics.RegisterMetricSource("recording", source)
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		Name:           "cpu",
+		MetricType:     "recording",
+		PrometheusPath: "http://prometheus:9090",
+		ExogenousRegressors: []scalingv1.AutoscalingDefinitionExogenousRegressor{
+			{Name: "requests", Query: "requests", MaxValue: "100"},
+			{Name: "orders", Query: "orders", PrometheusPath: "http://shop-prometheus:9090", MaxValue: "50"},
+		},
+	}
+	for _, regressor := range metrics.ExogenousRegressors(metric) {
+		if _, err := scrapeExogenousRegressor(metric, regressor, time.Now()); err != nil {
+			t.Fatalf("scrapeExogenousRegressor() of %s failed: %s", regressor.Name, err.Error())
+		}
+	}
+	expected := []string{"http://prometheus:9090", "http://shop-prometheus:9090"}
+	if !reflect.DeepEqual(source.addresses, expected) {
+		t.Errorf("regressors queried addresses %v, expected %v", source.addresses, expected)
+	}
+}
@@ -0,0 +1,98 @@
+package autoscaler
+
+import (
+	"context"
+	"custom-hpa/algorithm"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"log"
+	"strconv"
+	"time"
+)
+
+// externalEvaluator sends test history to external algorithm. Reactive evaluator is fed by every test result,
+// so that its evaluation is ready as fallback, when external algorithm fails or does not respond within timeout.
+type externalEvaluator struct {
+	metric           scalingv1.AutoscalingDefinitionMetric
+	client           algorithm.Client
+	timeout          time.Duration
+	history          []algorithm.Sample
+	exogenousHistory map[string][]algorithm.Sample
+	fallback         *reactiveEvaluator
+}
+
+func newExternalEvaluator(metric scalingv1.AutoscalingDefinitionMetric) *externalEvaluator {
+	metrics.FillEmptyMetricFields(&metric)
+	client, err := algorithm.NewClient(metric.ExternalProtocol, metric.ExternalEndpoint)
+	if err != nil {
+		log.Printf("External algorithm error of metric %s: %s", metric.Name, err.Error())
+	}
+	timeout, _ := time.ParseDuration(metric.ExternalTimeout)
+	return &externalEvaluator{
+		metric:           metric,
+		client:           client,
+		timeout:          timeout,
+		exogenousHistory: map[string][]algorithm.Sample{},
+		fallback:         newReactiveEvaluator(metric),
+	}
+}
+
+func (e *externalEvaluator) Evaluate(testResult metrics.TestResult, exogenousRegressor ExogenousRegressorScrapeResult, now time.Time) AutoscaleEvaluation {
+	ae := e.fallback.Evaluate(testResult, exogenousRegressor, now)
+	if exogenousRegressor.IsValid {
+		ae.ExogenousRegressors = exogenousRegressor.Values
+	}
+	if !testResult.IsValid {
+		return ae
+	}
+	e.history = appendSample(e.history, algorithm.Sample{Time: now, Value: testResult.Value}, e.metric.ExternalHistoryLength)
+	for _, value := range ae.ExogenousRegressors {
+		e.exogenousHistory[value.Name] = appendSample(e.exogenousHistory[value.Name], algorithm.Sample{Time: now, Value: value.Value}, e.metric.ExternalHistoryLength)
+	}
+	if e.client == nil {
+		return ae
+	}
+	scaleDownValue, _ := strconv.ParseFloat(e.metric.ScaleDownValue, 64)
+	scaleUpValue, _ := strconv.ParseFloat(e.metric.ScaleUpValue, 64)
+	request := algorithm.Request{
+		Metric:          e.metric.Name,
+		Time:            now,
+		History:         e.history,
+		ExogenousValues: e.exogenousHistory,
+		Replicas:        testResult.Replicas,
+		ScaleDownValue:  scaleDownValue,
+		ScaleUpValue:    scaleUpValue,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	response, err := e.client.Evaluate(ctx, request)
+	if err != nil {
+		log.Printf("External algorithm error of metric %s, falling back to reactive evaluation: %s", e.metric.Name, err.Error())
+		return ae
+	}
+	if response.Forecast != nil {
+		ae.PredictedValue, ae.HasPredictedValue = *response.Forecast, true
+		ae.ScaleDown, ae.ScaleUp = metrics.TestSingleValueBounds(e.metric, *response.Forecast)
+		ae.DesiredReplicas, ae.HasDesiredReplicas = 0, false
+		applyTotalValueScope(&ae, metrics.TestResult{IsValid: true, Replicas: testResult.Replicas, TotalValue: *response.Forecast * float64(testResult.Replicas)}, e.metric)
+	}
+	if response.DesiredReplicas != nil {
+		ae.ScaleDown, ae.ScaleUp = false, false
+		ae.DesiredReplicas, ae.HasDesiredReplicas = *response.DesiredReplicas, true
+	}
+	ae.Panic = false
+	return ae
+}
+
+// Clear drops test history of fallback evaluator only, history sent to external algorithm is kept for forecasting
+func (e *externalEvaluator) Clear() {
+	e.fallback.Clear()
+}
+
+func appendSample(samples []algorithm.Sample, sample algorithm.Sample, limit int) []algorithm.Sample {
+	samples = append(samples, sample)
+	if limit > 0 && len(samples) > limit {
+		samples = samples[len(samples)-limit:]
+	}
+	return samples
+}
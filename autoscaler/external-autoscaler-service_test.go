@@ -0,0 +1,133 @@
+package autoscaler
+
+import (
+	"custom-hpa/algorithm"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newExternalTestMetric(protocol string, endpoint string) scalingv1.AutoscalingDefinitionMetric {
+	return scalingv1.AutoscalingDefinitionMetric{
+		Name:                  "load",
+		Algorithm:             "external",
+		NumOfTests:            3,
+		ScaleValueType:        "double",
+		ScaleDownValue:        "20",
+		ScaleUpValue:          "50",
+		ExternalProtocol:      protocol,
+		ExternalEndpoint:      endpoint,
+		ExternalHistoryLength: 2,
+	}
+}
+
+func TestExternalEvaluator(t *testing.T) {
+	forecast := 80.0
+	desiredReplicas := 5
+	tests := []struct {
+		name                    string
+		response                algorithm.Response
+		expectedScaleUp         bool
+		expectedPredictedValue  bool
+		expectedDesiredReplicas int
+	}{
+		{
+			name:                   "forecast over scale up value",
+			response:               algorithm.Response{Forecast: &forecast},
+			expectedScaleUp:        true,
+			expectedPredictedValue: true,
+		},
+		{
+			name:                    "desired replicas",
+			response:                algorithm.Response{Forecast: &forecast, DesiredReplicas: &desiredReplicas},
+			expectedPredictedValue:  true,
+			expectedDesiredReplicas: 5,
+		},
+	}
+	for _, protocol := range []string{algorithm.ProtocolHttp, algorithm.ProtocolGrpc} {
+		for _, test := range tests {
+			t.Run(protocol+"/"+test.name, func(t *testing.T) {
+				response := test.response
+				server, err := algorithm.NewStubServer(protocol, "127.0.0.1:0", func(request algorithm.Request) (algorithm.Response, error) {
+					return response, nil
+				})
+				if err != nil {
+					t.Fatalf("cannot start stub server: %s", err.Error())
+				}
+				defer server.Close()
+				evaluator := NewEvaluator(newExternalTestMetric(protocol, server.Endpoint))
+
+				ae := evaluator.Evaluate(metrics.TestResult{IsValid: true, Value: 30, Replicas: 4}, ExogenousRegressorScrapeResult{}, time.Now())
+
+				if ae.ScaleUp != test.expectedScaleUp || ae.ScaleDown || ae.HasPredictedValue != test.expectedPredictedValue ||
+					ae.HasDesiredReplicas != (test.expectedDesiredReplicas > 0) || ae.DesiredReplicas != test.expectedDesiredReplicas {
+					t.Errorf("Evaluate() = %+v", ae)
+				}
+				requests := server.Requests()
+				if len(requests) != 1 || requests[0].Replicas != 4 || len(requests[0].History) != 1 || requests[0].ScaleUpValue != 50 {
+					t.Errorf("external algorithm received %+v", requests)
+				}
+			})
+		}
+	}
+}
+
+func TestExternalEvaluatorFallsBackToReactive(t *testing.T) {
+	server, err := algorithm.NewStubServer(algorithm.ProtocolHttp, "127.0.0.1:0", func(request algorithm.Request) (algorithm.Response, error) {
+		return algorithm.Response{}, errors.New("model is not trained")
+	})
+	if err != nil {
+		t.Fatalf("cannot start stub server: %s", err.Error())
+	}
+	defer server.Close()
+	evaluator := NewEvaluator(newExternalTestMetric(algorithm.ProtocolHttp, server.Endpoint))
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var ae AutoscaleEvaluation
+	for i := 0; i < 3; i++ {
+		ae = evaluator.Evaluate(metrics.TestResult{IsValid: true, Value: 60, UpperBoundTestPassed: true}, ExogenousRegressorScrapeResult{}, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	if !ae.ScaleUp || ae.HasPredictedValue || ae.HasDesiredReplicas {
+		t.Errorf("Evaluate() = %+v, expected reactive scale up", ae)
+	}
+	requests := server.Requests()
+	if len(requests) != 3 || len(requests[2].History) != 2 || !requests[2].History[1].Time.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("external algorithm received %+v, expected 3 requests with history of the latest 2 results", requests)
+	}
+}
+
+func TestExternalEvaluatorFallsBackToReactiveAfterTimeout(t *testing.T) {
+	for _, protocol := range []string{algorithm.ProtocolHttp, algorithm.ProtocolGrpc} {
+		t.Run(protocol, func(t *testing.T) {
+			server, err := algorithm.NewStubServer(protocol, "127.0.0.1:0", func(request algorithm.Request) (algorithm.Response, error) {
+				time.Sleep(500 * time.Millisecond)
+				desiredReplicas := 10
+				return algorithm.Response{DesiredReplicas: &desiredReplicas}, nil
+			})
+			if err != nil {
+				t.Fatalf("cannot start stub server: %s", err.Error())
+			}
+			defer server.Close()
+			metric := newExternalTestMetric(protocol, server.Endpoint)
+			metric.NumOfTests = 1
+			metric.ExternalTimeout = "50ms"
+			evaluator := NewEvaluator(metric)
+			start := time.Now()
+
+			ae := evaluator.Evaluate(metrics.TestResult{IsValid: true, Value: 60, UpperBoundTestPassed: true, Replicas: 4}, ExogenousRegressorScrapeResult{}, start)
+
+			if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+				t.Errorf("Evaluate() returned after %s, expected fallback after externalTimeout 50ms", elapsed)
+			}
+			if !ae.ScaleUp || ae.HasPredictedValue || ae.HasDesiredReplicas {
+				t.Errorf("Evaluate() = %+v, expected reactive scale up", ae)
+			}
+			if requests := server.Requests(); len(requests) != 1 {
+				t.Errorf("external algorithm received %d requests, expected 1", len(requests))
+			}
+		})
+	}
+}
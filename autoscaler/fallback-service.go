@@ -0,0 +1,91 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/pkg/client/clientset/versioned"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"log"
+	"sort"
+	"strings"
+)
+
+type fallbackState struct {
+	failedTests   map[string]int
+	activeMetrics map[string]bool
+}
+
+func newFallbackState() *fallbackState {
+	return &fallbackState{failedTests: make(map[string]int), activeMetrics: make(map[string]bool)}
+}
+
+func isFallbackEnabled(spec scalingv1.AutoscalingDefinitionSpec) bool {
+	return len(spec.Fallback.Behavior) > 0
+}
+
+// update counts consecutive failed tests of evaluated metric and reports whether fallback of the metric was activated or recovered
+func (f *fallbackState) update(ae AutoscaleEvaluation, failureThreshold int) bool {
+	if ae.MetricUnavailable {
+		f.failedTests[ae.Metric.Name]++
+	} else {
+		f.failedTests[ae.Metric.Name] = 0
+	}
+	isActive := f.failedTests[ae.Metric.Name] >= failureThreshold
+	changed := isActive != f.activeMetrics[ae.Metric.Name]
+	if isActive {
+		f.activeMetrics[ae.Metric.Name] = true
+	} else {
+		delete(f.activeMetrics, ae.Metric.Name)
+	}
+	return changed
+}
+
+// isActive reports whether fallback replaces evaluations of metric
+func (f *fallbackState) isActive(metricName string) bool {
+	return f.activeMetrics[metricName]
+}
+
+func (f *fallbackState) activeMetricNames() []string {
+	var names []string
+	for name := range f.activeMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyFallback replaces metric based evaluation with fallback behavior, second result is false when scaling should be held
+func applyFallback(ae AutoscaleEvaluation, fallback scalingv1.AutoscalingDefinitionFallback) (AutoscaleEvaluation, bool) {
+	switch strings.ToUpper(fallback.Behavior) {
+	case "SAFEREPLICAS":
+		return AutoscaleEvaluation{DesiredReplicas: fallback.Replicas, HasDesiredReplicas: true, Metric: ae.Metric}, true
+	case "SCALEUP":
+		return AutoscaleEvaluation{ScaleUp: true, Metric: ae.Metric}, true
+	default:
+		log.Printf("Metrics unavailable, holding replicas. Metric: %s", ae.Metric.Name)
+		return ae, false
+	}
+}
+
+// reportFallbackTransition records event of metric and sets MetricsAvailable condition, state is left to scaling control
+func reportFallbackTransition(client *kubernetes.Clientset, definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, metricName string, fallback *fallbackState) {
+	if fallback.isActive(metricName) {
+		message := fmt.Sprintf("Metric %s unavailable for %d consecutive tests, applying fallback behavior: %s", metricName, definition.Spec.Fallback.FailureThreshold, definition.Spec.Fallback.Behavior)
+		log.Print(message)
+		recordEvent(client, definition, corev1.EventTypeWarning, "FallbackActivated", message)
+	} else {
+		message := fmt.Sprintf("Metric %s available again, fallback behavior recovered", metricName)
+		log.Print(message)
+		recordEvent(client, definition, corev1.EventTypeNormal, "FallbackRecovered", message)
+	}
+	activeMetrics := fallback.activeMetricNames()
+	updateDefinitionStatus(definitionClient, definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+		if len(activeMetrics) > 0 {
+			message := fmt.Sprintf("Fallback behavior %s applied to metrics: %s", definition.Spec.Fallback.Behavior, strings.Join(activeMetrics, ", "))
+			setStatusCondition(status, "MetricsAvailable", corev1.ConditionFalse, "FallbackActivated", message)
+			return
+		}
+		setStatusCondition(status, "MetricsAvailable", corev1.ConditionTrue, "MetricsRecovered", "All metrics available")
+	})
+}
@@ -0,0 +1,36 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"testing"
+)
+
+func TestFallbackStateUpdate(t *testing.T) {
+	failing := scalingv1.AutoscalingDefinitionMetric{Name: "failing"}
+	healthy := scalingv1.AutoscalingDefinitionMetric{Name: "healthy"}
+	tests := []struct {
+		name            string
+		evaluation      AutoscaleEvaluation
+		expectedChanged bool
+		expectedActive  []string
+	}{
+		{name: "first failure", evaluation: AutoscaleEvaluation{MetricUnavailable: true, Metric: failing}},
+		{name: "healthy metric", evaluation: AutoscaleEvaluation{Metric: healthy}},
+		{name: "threshold reached", evaluation: AutoscaleEvaluation{MetricUnavailable: true, Metric: failing}, expectedChanged: true, expectedActive: []string{"failing"}},
+		{name: "healthy metric during fallback", evaluation: AutoscaleEvaluation{Metric: healthy}, expectedActive: []string{"failing"}},
+		{name: "failure during fallback", evaluation: AutoscaleEvaluation{MetricUnavailable: true, Metric: failing}, expectedActive: []string{"failing"}},
+		{name: "recovery", evaluation: AutoscaleEvaluation{Metric: failing}, expectedChanged: true},
+	}
+	fallback := newFallbackState()
+	for _, test := range tests {
+		if changed := fallback.update(test.evaluation, 2); changed != test.expectedChanged {
+			t.Errorf("%s: update() = %t, expected %t", test.name, changed, test.expectedChanged)
+		}
+		if fallback.isActive(healthy.Name) {
+			t.Errorf("%s: fallback is active for healthy metric", test.name)
+		}
+		if active := fallback.activeMetricNames(); len(active) != len(test.expectedActive) || (len(active) > 0 && active[0] != test.expectedActive[0]) {
+			t.Errorf("%s: active metrics %v, expected %v", test.name, active, test.expectedActive)
+		}
+	}
+}
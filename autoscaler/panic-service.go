@@ -0,0 +1,79 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"log"
+	"strconv"
+	"time"
+)
+
+type panicDetector struct {
+	scaleUpValue        float64
+	threshold           float64
+	window              []float64
+	windowSize          int
+	stabilizationPeriod time.Duration
+	isPanicking         bool
+	lastPanic           time.Time
+}
+
+// newPanicDetector returns nil when panicThreshold is not set, which disables panic mode for metric
+func newPanicDetector(metric scalingv1.AutoscalingDefinitionMetric) *panicDetector {
+	panicThreshold, err := strconv.ParseFloat(metric.PanicThreshold, 64)
+	if err != nil || panicThreshold <= 0 {
+		return nil
+	}
+	scaleUpValue, err := strconv.ParseFloat(metric.ScaleUpValue, 64)
+	if err != nil || scaleUpValue <= 0 {
+		log.Printf("Panic mode of metric %s requires positive numeric scaleUpValue", metric.Name)
+		return nil
+	}
+	windowSize := metric.PanicWindow
+	if windowSize <= 0 {
+		windowSize = 1
+	}
+	stabilizationPeriod, err := time.ParseDuration(metric.PanicStabilizationPeriod)
+	if err != nil {
+		stabilizationPeriod = 2 * time.Minute
+	}
+	return &panicDetector{
+		scaleUpValue:        scaleUpValue,
+		threshold:           panicThreshold * scaleUpValue,
+		windowSize:          windowSize,
+		stabilizationPeriod: stabilizationPeriod,
+	}
+}
+
+// update adds test result to panic window and marks evaluation as panic when mean of window exceeds threshold.
+// Scaling down is refused until panic window stays calm for stabilization period.
+func (p *panicDetector) update(testResult metrics.TestResult, ae *AutoscaleEvaluation, now time.Time) {
+	if testResult.IsValid {
+		p.window = append(p.window, testResult.Value)
+		if len(p.window) > p.windowSize {
+			p.window = p.window[1:]
+		}
+	}
+	if len(p.window) > 0 {
+		var sum = 0.0
+		for _, value := range p.window {
+			sum += value
+		}
+		mean := sum / float64(len(p.window))
+		if mean >= p.threshold {
+			if !p.isPanicking {
+				log.Printf("Entering panic mode, metric: %s, value: %f", testResult.MetricName, mean)
+			}
+			p.isPanicking = true
+			p.lastPanic = now
+			ae.Panic = true
+			ae.PanicRatio = mean / p.scaleUpValue
+		} else if p.isPanicking && now.Sub(p.lastPanic) >= p.stabilizationPeriod {
+			log.Printf("Leaving panic mode, metric: %s", testResult.MetricName)
+			p.isPanicking = false
+		}
+	}
+	if p.isPanicking {
+		ae.ScaleDown = false
+	}
+}
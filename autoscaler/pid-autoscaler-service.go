@@ -0,0 +1,107 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"custom-hpa/util"
+	"log"
+	"math"
+	"strconv"
+	"time"
+)
+
+type pidController struct {
+	targetValue      float64
+	proportionalGain float64
+	integralGain     float64
+	derivativeGain   float64
+	maxReplicaDelta  float64
+	previousErrors   []float64
+	remainder        float64
+}
+
+type pidEvaluator struct {
+	metric     scalingv1.AutoscalingDefinitionMetric
+	controller *pidController
+}
+
+func EvaluateAutoscalingPid(
+	resultChannel metrics.TestResultsChannel,
+	metric scalingv1.AutoscalingDefinitionMetric, clock util.Clock) AutoscaleEvaluationResult {
+	return startEvaluationProcess(newPidEvaluator(metric), resultChannel, nil, clock)
+}
+
+func newPidEvaluator(metric scalingv1.AutoscalingDefinitionMetric) *pidEvaluator {
+	return &pidEvaluator{metric: metric, controller: newPidController(metric)}
+}
+
+func (e *pidEvaluator) Evaluate(testResult metrics.TestResult, exogenousRegressor ExogenousRegressorScrapeResult, now time.Time) AutoscaleEvaluation {
+	if !testResult.IsValid {
+		return AutoscaleEvaluation{MetricUnavailable: true, Metric: e.metric}
+	}
+	ae := AutoscaleEvaluation{
+		ScaleDown:    false,
+		ScaleUp:      false,
+		ReplicaDelta: e.controller.update(testResult.Value),
+	}
+	ae.MetricValue, ae.HasMetricValue = testResult.Value, true
+	ae.ScrapeCount = testResult.ScrapeCount
+	ae.Metric = e.metric
+	return ae
+}
+
+// Clear drops carried fraction of delta only, previous errors are kept for proportional and derivative terms after scaling
+func (e *pidEvaluator) Clear() {
+	e.controller.remainder = 0
+}
+
+func newPidController(metric scalingv1.AutoscalingDefinitionMetric) *pidController {
+	maxReplicaDelta := metric.MaxReplicaDelta
+	if maxReplicaDelta <= 0 {
+		maxReplicaDelta = 2
+	}
+	return &pidController{
+		targetValue:      parsePidParameter(metric.TargetValue, "targetValue"),
+		proportionalGain: parsePidParameter(metric.ProportionalGain, "proportionalGain"),
+		integralGain:     parsePidParameter(metric.IntegralGain, "integralGain"),
+		derivativeGain:   parsePidParameter(metric.DerivativeGain, "derivativeGain"),
+		maxReplicaDelta:  float64(maxReplicaDelta),
+	}
+}
+
+// update advances the controller by one test interval and returns replica delta of velocity form of PID controller.
+// Delta is added to current replicas, so controller keeps no integral, which could wind up, when delta is not applied
+// during cooldown, pause or on replica bounds. Fraction of delta lost by rounding is carried to next test interval.
+func (c *pidController) update(measuredValue float64) int {
+	err := measuredValue - c.targetValue
+	proportional, derivative := 0.0, 0.0
+	if len(c.previousErrors) > 0 {
+		proportional = err - c.previousErrors[0]
+	}
+	if len(c.previousErrors) > 1 {
+		derivative = err - 2*c.previousErrors[0] + c.previousErrors[1]
+	}
+	c.previousErrors = append([]float64{err}, c.previousErrors...)
+	if len(c.previousErrors) > 2 {
+		c.previousErrors = c.previousErrors[:2]
+	}
+
+	output := c.proportionalGain*proportional + c.integralGain*err + c.derivativeGain*derivative + c.remainder
+	clampedOutput := math.Max(-c.maxReplicaDelta, math.Min(c.maxReplicaDelta, output))
+	delta := math.Round(clampedOutput)
+	c.remainder = 0
+	if output == clampedOutput {
+		c.remainder = output - delta
+	}
+	log.Printf("PID error: %f, output: %f, remainder: %f", err, clampedOutput, c.remainder)
+	return int(delta)
+}
+
+func parsePidParameter(value string, name string) float64 {
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("Float conversion error - %s: %s", name, err.Error())
+		return 0
+	}
+	return result
+}
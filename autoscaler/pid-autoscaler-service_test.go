@@ -0,0 +1,59 @@
+package autoscaler
+
+import (
+	"testing"
+)
+
+func TestPidControllerUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		controller  pidController
+		values      []float64
+		clearBefore map[int]bool
+		expected    []int
+	}{
+		{
+			name:       "constant error does not wind up",
+			controller: pidController{targetValue: 10, proportionalGain: 1, integralGain: 0.5, maxReplicaDelta: 2},
+			values:     []float64{12, 12, 12, 12},
+			expected:   []int{1, 1, 1, 1},
+		},
+		{
+			name:       "proportional term reacts to error change only",
+			controller: pidController{targetValue: 10, proportionalGain: 1, maxReplicaDelta: 5},
+			values:     []float64{10, 13, 13, 11},
+			expected:   []int{0, 3, 0, -2},
+		},
+		{
+			name:       "rounded fraction is carried",
+			controller: pidController{targetValue: 10, integralGain: 0.2, maxReplicaDelta: 2},
+			values:     []float64{11, 11, 11, 11, 11},
+			expected:   []int{0, 0, 1, 0, 0},
+		},
+		{
+			name:       "output is clamped without carrying",
+			controller: pidController{targetValue: 10, integralGain: 1, maxReplicaDelta: 2},
+			values:     []float64{20, 20, 10},
+			expected:   []int{2, 2, 0},
+		},
+		{
+			name:        "previous errors are kept after clear",
+			controller:  pidController{targetValue: 10, proportionalGain: 1, derivativeGain: 1, maxReplicaDelta: 5},
+			values:      []float64{10, 13, 13, 11},
+			clearBefore: map[int]bool{3: true},
+			expected:    []int{0, 3, -3, -4},
+		},
+	}
+	for _, test := range tests {
+		controller := test.controller
+		evaluator := pidEvaluator{controller: &controller}
+		for i, value := range test.values {
+			if test.clearBefore[i] {
+				evaluator.Clear()
+			}
+			if delta := controller.update(value); delta != test.expected[i] {
+				t.Errorf("%s: delta of update %d is %d, expected %d", test.name, i, delta, test.expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package autoscaler
+
+import (
+	"fmt"
+	"math"
+)
+
+type scalingPlan struct {
+	Replicas int
+	Policies []string
+	Reason   string
+}
+
+// applyPanicScaling replaces evaluation by desired replicas, when panic mode requires more replicas than target has.
+func applyPanicScaling(ae AutoscaleEvaluation, replicas int, bounds ReplicaBounds) (AutoscaleEvaluation, bool) {
+	if !ae.Panic || replicas <= 0 {
+		return ae, false
+	}
+	desiredReplicas := int(math.Ceil(float64(replicas) * ae.PanicRatio))
+	desiredReplicas = int(math.Min(float64(bounds.MaxReplicas), float64(desiredReplicas)))
+	if desiredReplicas <= replicas {
+		return ae, false
+	}
+	return AutoscaleEvaluation{DesiredReplicas: desiredReplicas, HasDesiredReplicas: true, Metric: ae.Metric}, true
+}
+
+// planScaling calculates replicas required by evaluation, plan keeps current replicas when no scaling is possible.
+// It has no side effects, so that it is shared by autoscaling process and simulator.
+func planScaling(ae AutoscaleEvaluation, replicas int, bounds ReplicaBounds, control ScalingControl, restriction RolloutRestriction, scalingStep int, panicScaling bool) scalingPlan {
+	scaleDownFrozen := control.IsScaleDownFrozen || restriction.ScaleDownBlocked
+	scaleDownRequested := ae.ScaleDown
+	if scaleDownFrozen {
+		ae.ScaleDown = false
+	}
+	switch {
+	case ae.HasDesiredReplicas || ae.ReplicaDelta != 0:
+		plan := scalingPlan{
+			Replicas: ae.DesiredReplicas,
+			Policies: []string{evaluationPolicy(ae, bounds, control, panicScaling)},
+			Reason:   evaluationReason(ae, bounds, control, panicScaling),
+		}
+		if !ae.HasDesiredReplicas {
+			plan.Replicas = replicas + ae.ReplicaDelta
+		}
+		if bounded := int(math.Max(float64(bounds.MinReplicas), math.Min(float64(bounds.MaxReplicas), float64(plan.Replicas)))); bounded != plan.Replicas {
+			plan.Replicas = bounded
+			plan.Policies = append(plan.Policies, PolicyReplicaBounds)
+		}
+		if scaleDownFrozen && plan.Replicas < replicas {
+			plan.Replicas = replicas
+			plan.Policies = append(plan.Policies, PolicyScaleDownFrozen)
+		}
+		if restriction.IsCapped && plan.Replicas > replicas+scalingStep {
+			plan.Replicas = replicas + scalingStep
+			plan.Policies = append(plan.Policies, PolicyRolloutCapped)
+		}
+		if plan.Replicas == replicas {
+			plan.Reason = "no need to scale or replicas bound reached"
+		}
+		return plan
+	case ae.ScaleUp && replicas < bounds.MaxReplicas:
+		plan := scalingPlan{
+			Replicas: int(math.Min(float64(bounds.MaxReplicas), float64(replicas+scalingStep))),
+			Policies: []string{PolicyScalingStep},
+			Reason:   "upper bound test of metric " + ae.Metric.Name + " passed",
+		}
+		if replicas+scalingStep > bounds.MaxReplicas {
+			plan.Policies = append(plan.Policies, PolicyReplicaBounds)
+		}
+		return plan
+	case ae.ScaleDown && replicas > bounds.MinReplicas:
+		plan := scalingPlan{
+			Replicas: int(math.Max(float64(bounds.MinReplicas), float64(replicas-scalingStep))),
+			Policies: []string{PolicyScalingStep},
+			Reason:   "lower bound test of metric " + ae.Metric.Name + " passed",
+		}
+		if replicas-scalingStep < bounds.MinReplicas {
+			plan.Policies = append(plan.Policies, PolicyReplicaBounds)
+		}
+		return plan
+	case ae.ScaleUp:
+		return scalingPlan{Replicas: replicas, Policies: []string{PolicyReplicaBounds}, Reason: "maximum replicas reached"}
+	case ae.ScaleDown:
+		return scalingPlan{Replicas: replicas, Policies: []string{PolicyReplicaBounds}, Reason: "minimum replicas reached"}
+	case scaleDownRequested && scaleDownFrozen:
+		return scalingPlan{Replicas: replicas, Policies: []string{PolicyScaleDownFrozen}, Reason: "scale down frozen"}
+	}
+	return scalingPlan{Replicas: replicas, Reason: "no need to scale"}
+}
+
+func evaluationPolicy(ae AutoscaleEvaluation, bounds ReplicaBounds, control ScalingControl, panicScaling bool) string {
+	switch {
+	case control.IsPinned || bounds.IsPinned:
+		return PolicyPinned
+	case panicScaling:
+		return PolicyPanic
+	case ae.HasDesiredReplicas:
+		return PolicyDesiredReplicas
+	}
+	return PolicyReplicaDelta
+}
+
+func evaluationReason(ae AutoscaleEvaluation, bounds ReplicaBounds, control ScalingControl, panicScaling bool) string {
+	switch {
+	case control.IsPinned:
+		return control.Message()
+	case bounds.IsPinned:
+		return "replicas pinned by schedule " + bounds.ScheduleName
+	case panicScaling:
+		return "metric " + ae.Metric.Name + " exceeded panic threshold"
+	case ae.HasDesiredReplicas:
+		return fmt.Sprintf("metric %s requires %d replicas", ae.Metric.Name, ae.DesiredReplicas)
+	}
+	return fmt.Sprintf("metric %s requires change of %d replicas", ae.Metric.Name, ae.ReplicaDelta)
+}
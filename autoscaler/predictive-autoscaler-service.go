@@ -2,125 +2,148 @@ package autoscaler
 
 import (
 	"container/ring"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/metrics"
-	"custom-hpa/model"
+	"custom-hpa/util"
 	"log"
 	"math"
 	"strconv"
+	"time"
 )
 
+// predictiveEvaluator tests the latest results and prediction of seasonal arimax model. History of model is sized by its lags
+// and differencing, predictions are kept aligned with it for moving average terms.
+type predictiveEvaluator struct {
+	metric                scalingv1.AutoscalingDefinitionMetric
+	resultBuffer          *ring.Ring
+	historyBuffer         *ring.Ring
+	predictionBuffer      *ring.Ring
+	requiredPositiveTests int
+}
+
+// sarimaModel holds lag polynomials of multiplicative seasonal model, coefficient at index i belongs to lag i+1.
+// Autoregression is applied to differenced values, moving average to errors of previous predictions and differencing
+// integrates differenced prediction back to metric value.
+type sarimaModel struct {
+	autoregression []float64
+	movingAverage  []float64
+	differencing   []float64
+	intercept      float64
+}
+
 func EvaluateAutoscalingPredictive(
 	resultChannel metrics.TestResultsChannel,
 	exogenousRegressorResultChannel chan ExogenousRegressorScrapeResult,
-	metric model.AutoscalingDefinitionMetric) AutoscaleEvaluationResult {
-
-	autoscaleEvaluationChannel := make(chan AutoscaleEvaluation)
-	closeEvaluationProcessChannel := make(chan bool)
-	clearBufferChannel := make(chan bool)
-	go func() {
-		var resultBuffer *ring.Ring = nil
-		ad := metric.AutoregresionDegree
-		mad := metric.MovingAverageDegree
-		if metric.NumOfTests > ad {
-			resultBuffer = ring.New(metric.NumOfTests)
-		} else {
-			resultBuffer = ring.New(ad)
-		}
-		var predictionBuffer *ring.Ring = nil
-		if metric.NumOfTests > mad {
-			predictionBuffer = ring.New(metric.NumOfTests)
-		} else {
-			predictionBuffer = ring.New(mad)
-		}
-		var requiredPositiveTests = int(math.Round(float64(metric.NumOfTests+1) / 2.0))
-
-		for {
-			select {
-			case testResult := <-resultChannel.TestResultsChannel:
-				resultBuffer.Value = testResult
-				resultBuffer = resultBuffer.Next()
-				validatePredictedValue(testResult, predictionBuffer)
-				exogenousRegressor := <-exogenousRegressorResultChannel
-				if exogenousRegressor.IsValid {
-					predictionBuffer = calculatePredictedMetricValue(metric, resultBuffer, predictionBuffer, exogenousRegressor.Value)
-				}
-				ae := checkBufferPredictive(resultBuffer, predictionBuffer, requiredPositiveTests, metric.NumOfTests)
-				ae.Metric = metric
-				autoscaleEvaluationChannel <- ae
-				resultBuffer.Value = nil
-			case <-closeEvaluationProcessChannel:
-				return
-			case <-clearBufferChannel:
-				clearBuffer(resultBuffer)
-			}
-		}
-	}()
-	return AutoscaleEvaluationResult{
-		AutoscaleEvaluation:           autoscaleEvaluationChannel,
-		CloseEvaluationProcessChannel: closeEvaluationProcessChannel,
-		ClearBufferChannel:            clearBufferChannel,
+	metric scalingv1.AutoscalingDefinitionMetric, clock util.Clock) AutoscaleEvaluationResult {
+	return startEvaluationProcess(newPredictiveEvaluator(metric), resultChannel, exogenousRegressorResultChannel, clock)
+}
+
+// newPredictiveEvaluator starts with empty history, model predicts after it is filled by valid test results also after restart
+func newPredictiveEvaluator(metric scalingv1.AutoscalingDefinitionMetric) *predictiveEvaluator {
+	model := newSarimaModel(metric)
+	numOfTests := maxInt(metric.NumOfTests, 1)
+	log.Printf("Predictive model of metric %s predicts after %d valid test results", metric.Name, model.historyLength())
+	return &predictiveEvaluator{
+		metric:                metric,
+		resultBuffer:          ring.New(numOfTests),
+		historyBuffer:         ring.New(model.historyLength()),
+		predictionBuffer:      ring.New(maxInt(numOfTests, len(model.movingAverage))),
+		requiredPositiveTests: int(math.Round(float64(metric.NumOfTests+1) / 2.0)),
 	}
 }
 
-func calculatePredictedMetricValue(metric model.AutoscalingDefinitionMetric, resultBuffer *ring.Ring, predictionBuffer *ring.Ring, exogenousRegressor float64) *ring.Ring {
-	resultBufferPtr := resultBuffer
-	ad := metric.AutoregresionDegree
-	if bufferFulfillmentDegree(resultBufferPtr) < ad {
-		return predictionBuffer
+// Evaluate skips invalid test results, so that unavailable metric is not taken as zero value by model
+func (e *predictiveEvaluator) Evaluate(testResult metrics.TestResult, exogenousRegressor ExogenousRegressorScrapeResult, now time.Time) AutoscaleEvaluation {
+	if !testResult.IsValid {
+		ae := AutoscaleEvaluation{MetricUnavailable: true, Metric: e.metric}
+		ae.HistoryLength, ae.RequiredHistoryLength = e.historyLength()
+		return ae
 	}
-	mad := metric.MovingAverageDegree
-	if bufferFulfillmentDegree(resultBufferPtr) < mad {
-		return predictionBuffer
+	e.resultBuffer.Value = testResult
+	e.resultBuffer = e.resultBuffer.Next()
+	e.historyBuffer.Value = testResult
+	e.historyBuffer = e.historyBuffer.Next()
+	validatePredictedValue(testResult, e.predictionBuffer)
+	predictionBuffer := e.predictionBuffer
+	if exogenousRegressor.IsValid {
+		predictionBuffer = calculatePredictedMetricValue(e.metric, e.historyBuffer, e.predictionBuffer, exogenousRegressor.Values)
+	}
+	if predictionBuffer == e.predictionBuffer {
+		// empty prediction keeps predictions aligned with history
+		predictionBuffer.Value = nil
+		predictionBuffer = predictionBuffer.Next()
+	}
+	e.predictionBuffer = predictionBuffer
+	ae := checkBufferPredictive(e.resultBuffer, e.predictionBuffer, e.requiredPositiveTests, e.metric.NumOfTests)
+	ae.Metric = e.metric
+	ae.MetricValue, ae.HasMetricValue = testResult.Value, true
+	ae.ScrapeCount, ae.NumOfTests = testResult.ScrapeCount, e.metric.NumOfTests
+	ae.HistoryLength, ae.RequiredHistoryLength = e.historyLength()
+	if prediction, ok := e.predictionBuffer.Prev().Value.(metrics.TestResult); ok {
+		ae.PredictedValue, ae.HasPredictedValue = prediction.Value, true
 	}
+	if exogenousRegressor.IsValid {
+		ae.ExogenousRegressors = exogenousRegressor.Values
+	}
+	applyTotalValueScope(&ae, testResult, e.metric)
+	e.resultBuffer.Value = nil
+	return ae
+}
 
-	var predictedValue = 0.0 // predicted value variable
+// historyLength returns number of valid test results in history and number required by model to predict
+func (e *predictiveEvaluator) historyLength() (int, int) {
+	return len(recentValues(e.historyBuffer, e.historyBuffer.Len())), e.historyBuffer.Len()
+}
 
-	// AR
-	for i := 0; i < ad; i++ {
-		autregressionCoefficientString := metric.AutoregressionCoefficients[i]
-		autregressionCoefficient, err := strconv.ParseFloat(autregressionCoefficientString, 64)
-		if err != nil {
-			log.Printf("Float conversion error - autregressionCoefficients: %s", err.Error())
-			continue
-		}
-		resultBufferPtr = resultBufferPtr.Prev()
-		if resultBufferPtr.Value != nil {
-			testResult := resultBufferPtr.Value.(metrics.TestResult)
-			predictedValue += autregressionCoefficient * testResult.Value
-		}
+// Clear drops results of bound tests, history of model is kept, so that seasonal terms are not lost after scaling
+func (e *predictiveEvaluator) Clear() {
+	clearBuffer(e.resultBuffer)
+}
+
+// calculatePredictedMetricValue predicts next value from history in resultBuffer and errors of predictions in predictionBuffer,
+// exogenous regressors and intercept are added to differenced prediction
+func calculatePredictedMetricValue(metric scalingv1.AutoscalingDefinitionMetric, resultBuffer *ring.Ring, predictionBuffer *ring.Ring, exogenousRegressors []ExogenousRegressorValue) *ring.Ring {
+	model := newSarimaModel(metric)
+	values := recentValues(resultBuffer, model.historyLength())
+	if len(values) < model.historyLength() {
+		return predictionBuffer
+	}
+	var predictedValue = model.intercept // predicted value variable
+
+	// AR of differenced values
+	for i, coef := range model.autoregression {
+		predictedValue += coef * model.differenced(values, i)
 	}
 
-	resultBufferPtr = resultBuffer
+	// MA, missing prediction is counted as zero
 	predictionBufferPtr := predictionBuffer
+	for j, coef := range model.movingAverage {
+		predictionBufferPtr = predictionBufferPtr.Prev()
+		predictionValue := 0.0
+		if prediction, ok := predictionBufferPtr.Value.(metrics.TestResult); ok {
+			predictionValue = prediction.Value
+		}
+		noiseValue := values[j] - predictionValue
+		predictedValue += coef * noiseValue
+	}
 
-	// MA
-	for j := 0; j < mad; j++ {
-		movingAverageCoeffitientString := metric.MovingAverageCoefficients[j]
-		movingAverageCoeffitient, err := strconv.ParseFloat(movingAverageCoeffitientString, 64)
-		if err != nil {
-			log.Printf("Float conversion error - movingAverageCoeffitients: %s", err.Error())
-			continue
+	// Exogenous variables, values are ordered as regressors of metric
+	regressors := metrics.ExogenousRegressors(metric)
+	for i, exogenousRegressor := range exogenousRegressors {
+		if i >= len(regressors) {
+			break
 		}
-		resultBufferPtr = resultBufferPtr.Prev()
-		predictionBufferPtr = predictionBufferPtr.Prev()
-		if resultBufferPtr.Value != nil {
-			testResult := resultBufferPtr.Value.(metrics.TestResult)
-			predictionValueResult := predictionBufferPtr.Value
-			predictionValue := 0.0
-			if predictionValueResult != nil {
-				predictionValue = predictionValueResult.(metrics.TestResult).Value
-			}
-			noiseValue := testResult.Value - predictionValue
-			predictedValue += movingAverageCoeffitient * noiseValue
+		exogenousRegressorCoefficient, err := strconv.ParseFloat(regressors[i].Coefficient, 64)
+		if err != nil {
+			return predictionBuffer
 		}
+		predictedValue += exogenousRegressorCoefficient * exogenousRegressor.Value
 	}
 
-	// Exogenous variable
-	exogenousRegressorCoefficient, err := strconv.ParseFloat(metric.ExogenousRegressorCoefficient, 64)
-	if err != nil {
-		return predictionBuffer
+	// integration of differenced prediction
+	for k, coef := range model.differencing {
+		predictedValue -= coef * values[k]
 	}
-	predictedValue += exogenousRegressorCoefficient * exogenousRegressor
 
 	// result validation
 	lower, upper := metrics.TestSingleValueBounds(metric, predictedValue)
@@ -134,6 +157,106 @@ func calculatePredictedMetricValue(metric model.AutoscalingDefinitionMetric, res
 	return predictionBuffer.Next()
 }
 
+func newSarimaModel(metric scalingv1.AutoscalingDefinitionMetric) sarimaModel {
+	period := metric.SeasonalPeriod
+	// (1 - AR(B)) * (1 - SAR(B^s)) = 1 - autoregression(B)
+	autoregression := multiplyPolynomials(
+		lagPolynomial(parseCoefficients(metric.AutoregressionCoefficients, metric.AutoregresionDegree, "autoregressionCoefficients"), 1, -1),
+		lagPolynomial(parseCoefficients(metric.SeasonalAutoregressionCoefficients, metric.SeasonalAutoregressionDegree, "seasonalAutoregressionCoefficients"), period, -1))
+	// (1 + MA(B)) * (1 + SMA(B^s)) = 1 + movingAverage(B)
+	movingAverage := multiplyPolynomials(
+		lagPolynomial(parseCoefficients(metric.MovingAverageCoefficients, metric.MovingAverageDegree, "movingAverageCoefficients"), 1, 1),
+		lagPolynomial(parseCoefficients(metric.SeasonalMovingAverageCoefficients, metric.SeasonalMovingAverageDegree, "seasonalMovingAverageCoefficients"), period, 1))
+	// (1 - B)^d * (1 - B^s)^D
+	differencing := []float64{1}
+	for i := 0; i < metric.DifferencingOrder; i++ {
+		differencing = multiplyPolynomials(differencing, lagPolynomial([]float64{1}, 1, -1))
+	}
+	for i := 0; i < metric.SeasonalDifferencingOrder; i++ {
+		differencing = multiplyPolynomials(differencing, lagPolynomial([]float64{1}, period, -1))
+	}
+	model := sarimaModel{movingAverage: movingAverage[1:], differencing: differencing[1:]}
+	for _, coef := range autoregression[1:] {
+		model.autoregression = append(model.autoregression, -coef)
+	}
+	if intercept, err := strconv.ParseFloat(metric.Intercept, 64); err == nil {
+		model.intercept = intercept
+	}
+	return model
+}
+
+// historyLength is number of the latest values required by prediction
+func (m sarimaModel) historyLength() int {
+	return maxInt(maxInt(len(m.autoregression)+len(m.differencing), len(m.movingAverage)), 1)
+}
+
+// differenced returns differenced value at index i of values ordered from the latest
+func (m sarimaModel) differenced(values []float64, i int) float64 {
+	value := values[i]
+	for k, coef := range m.differencing {
+		value += coef * values[i+k+1]
+	}
+	return value
+}
+
+func parseCoefficients(coefficients []string, degree int, name string) []float64 {
+	result := make([]float64, degree)
+	for i := 0; i < degree && i < len(coefficients); i++ {
+		coef, err := strconv.ParseFloat(coefficients[i], 64)
+		if err != nil {
+			log.Printf("Float conversion error - %s: %s", name, err.Error())
+			continue
+		}
+		result[i] = coef
+	}
+	return result
+}
+
+// lagPolynomial returns polynomial 1 + sign * (c1 * B^step + c2 * B^(2*step) + ...) as coefficients from lag 0
+func lagPolynomial(coefficients []float64, step int, sign float64) []float64 {
+	if step <= 0 || len(coefficients) <= 0 {
+		return []float64{1}
+	}
+	polynomial := make([]float64, len(coefficients)*step+1)
+	polynomial[0] = 1
+	for i, coef := range coefficients {
+		polynomial[(i+1)*step] = sign * coef
+	}
+	return polynomial
+}
+
+func multiplyPolynomials(a []float64, b []float64) []float64 {
+	result := make([]float64, len(a)+len(b)-1)
+	for i := range a {
+		for j := range b {
+			result[i+j] += a[i] * b[j]
+		}
+	}
+	return result
+}
+
+// recentValues returns values of test results in buffer from the latest, it stops at the first empty item
+func recentValues(buffer *ring.Ring, n int) []float64 {
+	var values []float64
+	bufferPtr := buffer
+	for i := 0; i < n && i < buffer.Len(); i++ {
+		bufferPtr = bufferPtr.Prev()
+		testResult, ok := bufferPtr.Value.(metrics.TestResult)
+		if !ok {
+			break
+		}
+		values = append(values, testResult.Value)
+	}
+	return values
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func checkBufferPredictive(buffer *ring.Ring, predictionBuffer *ring.Ring, requiredPositiveTests int, numOfTests int) AutoscaleEvaluation {
 	if !isBufferFilled(buffer) {
 		return AutoscaleEvaluation{
@@ -169,8 +292,10 @@ func checkBufferPredictive(buffer *ring.Ring, predictionBuffer *ring.Ring, requi
 	}
 
 	var result = AutoscaleEvaluation{
-		ScaleDown: false,
-		ScaleUp:   false,
+		ScaleDown:       false,
+		ScaleUp:         false,
+		LowerBoundTests: scaleDownCounter,
+		UpperBoundTests: scaleUpCounter,
 	}
 	if scaleDownCounter >= requiredPositiveTests {
 		result.ScaleDown = true
@@ -0,0 +1,275 @@
+package autoscaler
+
+import (
+	"container/ring"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckBufferPredictive(t *testing.T) {
+	lower := metrics.TestResult{LowerBoundTestPassed: true}
+	upper := metrics.TestResult{UpperBoundTestPassed: true}
+	tests := []struct {
+		name              string
+		buffer            *ring.Ring
+		predictionBuffer  *ring.Ring
+		expectedScaleUp   bool
+		expectedScaleDown bool
+		expectedUpper     int
+		expectedLower     int
+	}{
+		{
+			name:             "buffer not filled",
+			buffer:           newTestResultBuffer(3, upper, upper),
+			predictionBuffer: newTestResultBuffer(3, upper),
+		},
+		{
+			name:             "falls back to reactive without prediction",
+			buffer:           newTestResultBuffer(3, upper, upper, upper),
+			predictionBuffer: ring.New(3),
+			expectedScaleUp:  true,
+			expectedUpper:    3,
+		},
+		{
+			// the oldest result is replaced by prediction
+			name:              "prediction replaces the oldest result",
+			buffer:            newTestResultBuffer(3, upper, lower, lower),
+			predictionBuffer:  newTestResultBuffer(3, lower),
+			expectedScaleDown: true,
+			expectedLower:     3,
+		},
+		{
+			name:             "prediction does not pass",
+			buffer:           newTestResultBuffer(3, lower, upper, upper),
+			predictionBuffer: newTestResultBuffer(3, metrics.TestResult{}),
+			expectedUpper:    2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := checkBufferPredictive(test.buffer, test.predictionBuffer, 3, 3)
+			if result.ScaleUp != test.expectedScaleUp || result.ScaleDown != test.expectedScaleDown ||
+				result.UpperBoundTests != test.expectedUpper || result.LowerBoundTests != test.expectedLower {
+				t.Errorf("checkBufferPredictive() = %+v", result)
+			}
+		})
+	}
+}
+
+func TestCalculatePredictedMetricValue(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		Name:                          "cpu",
+		ScaleDownValue:                "10",
+		ScaleUpValue:                  "50",
+		AutoregresionDegree:           2,
+		AutoregressionCoefficients:    []string{"0.5", "0.25"},
+		MovingAverageDegree:           1,
+		MovingAverageCoefficients:     []string{"0.1"},
+		ExogenousRegressorQuery:       "rate",
+		ExogenousRegressorCoefficient: "2",
+	}
+	multipleRegressorsMetric := metric
+	multipleRegressorsMetric.ExogenousRegressorQuery, multipleRegressorsMetric.ExogenousRegressorCoefficient = "", ""
+	multipleRegressorsMetric.ExogenousRegressors = []scalingv1.AutoscalingDefinitionExogenousRegressor{
+		{Name: "requests", Query: "rate", Coefficient: "2", MaxValue: "100"},
+		{Name: "misses", Query: "misses", Coefficient: "-1", MaxValue: "100"},
+	}
+	legacyRegressor := []ExogenousRegressorValue{{Name: metrics.ExogenousRegressorName, Value: 3}}
+	bounds := scalingv1.AutoscalingDefinitionMetric{Name: "cpu", ScaleDownValue: "1", ScaleUpValue: "50"}
+	differencedMetric := bounds
+	differencedMetric.AutoregresionDegree, differencedMetric.AutoregressionCoefficients = 1, []string{"0.5"}
+	differencedMetric.DifferencingOrder, differencedMetric.Intercept = 1, "2"
+	seasonalMetric := bounds
+	seasonalMetric.SeasonalPeriod, seasonalMetric.SeasonalAutoregressionDegree, seasonalMetric.SeasonalAutoregressionCoefficients = 3, 1, []string{"0.8"}
+	multiplicativeMetric := bounds
+	multiplicativeMetric.AutoregresionDegree, multiplicativeMetric.AutoregressionCoefficients = 1, []string{"0.5"}
+	multiplicativeMetric.SeasonalPeriod, multiplicativeMetric.SeasonalAutoregressionDegree, multiplicativeMetric.SeasonalAutoregressionCoefficients = 2, 1, []string{"0.5"}
+	seasonalDifferencedMetric := bounds
+	seasonalDifferencedMetric.SeasonalPeriod, seasonalDifferencedMetric.SeasonalDifferencingOrder, seasonalDifferencedMetric.Intercept = 2, 1, "1"
+	tests := []struct {
+		name             string
+		metric           scalingv1.AutoscalingDefinitionMetric
+		resultBuffer     *ring.Ring
+		predictionBuffer *ring.Ring
+		exogenous        []ExogenousRegressorValue
+		expectedValue    float64
+		expectedUpper    bool
+		expectedNone     bool
+	}{
+		{
+			// 0.5*60 + 0.25*20 + 0.1*(60-0) + 2*3
+			name:             "without previous prediction",
+			metric:           metric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 40}, metrics.TestResult{Value: 20}, metrics.TestResult{Value: 60}),
+			predictionBuffer: ring.New(3),
+			exogenous:        legacyRegressor,
+			expectedValue:    47,
+		},
+		{
+			// 0.5*60 + 0.25*20 + 0.1*(60-55) + 2*3
+			name:             "with previous prediction",
+			metric:           metric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 40}, metrics.TestResult{Value: 20}, metrics.TestResult{Value: 60}),
+			predictionBuffer: newTestResultBuffer(3, metrics.TestResult{Value: 55}),
+			exogenous:        legacyRegressor,
+			expectedValue:    41.5,
+		},
+		{
+			// 0.5*100 + 0.25*80 + 0.1*100 + 2*3
+			name:             "predicted value over scale up value",
+			metric:           metric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 80}, metrics.TestResult{Value: 100}),
+			predictionBuffer: ring.New(3),
+			exogenous:        legacyRegressor,
+			expectedValue:    86,
+			expectedUpper:    true,
+		},
+		{
+			// 0.5*60 + 0.25*20 + 0.1*(60-0) + 2*3 - 1*4
+			name:             "multiple exogenous regressors",
+			metric:           multipleRegressorsMetric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 40}, metrics.TestResult{Value: 20}, metrics.TestResult{Value: 60}),
+			predictionBuffer: ring.New(3),
+			exogenous:        []ExogenousRegressorValue{{Name: "requests", Value: 3}, {Name: "misses", Value: 4}},
+			expectedValue:    43,
+		},
+		{
+			// 0.5*(40-20) + 2 integrated to 40
+			name:             "differencing and intercept",
+			metric:           differencedMetric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 10}, metrics.TestResult{Value: 20}, metrics.TestResult{Value: 40}),
+			predictionBuffer: ring.New(3),
+			expectedValue:    52,
+			expectedUpper:    true,
+		},
+		{
+			// 0.8*20 of the previous season
+			name:             "seasonal autoregression",
+			metric:           seasonalMetric,
+			resultBuffer:     newTestResultBuffer(5, metrics.TestResult{Value: 10}, metrics.TestResult{Value: 30}, metrics.TestResult{Value: 20}, metrics.TestResult{Value: 11}, metrics.TestResult{Value: 31}),
+			predictionBuffer: ring.New(3),
+			expectedValue:    16,
+		},
+		{
+			// (1-0.5B)(1-0.5B^2) gives 0.5*8 + 0.5*4 - 0.25*2
+			name:             "multiplicative seasonal autoregression",
+			metric:           multiplicativeMetric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 2}, metrics.TestResult{Value: 4}, metrics.TestResult{Value: 8}),
+			predictionBuffer: ring.New(3),
+			expectedValue:    5.5,
+		},
+		{
+			// value of the previous season and intercept
+			name:             "seasonal differencing",
+			metric:           seasonalDifferencedMetric,
+			resultBuffer:     newTestResultBuffer(2, metrics.TestResult{Value: 4}, metrics.TestResult{Value: 8}),
+			predictionBuffer: ring.New(3),
+			expectedValue:    5,
+		},
+		{
+			name:             "not enough results for seasonal autoregression",
+			metric:           seasonalMetric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 11}, metrics.TestResult{Value: 31}),
+			predictionBuffer: ring.New(3),
+			expectedNone:     true,
+		},
+		{
+			name:             "not enough results for autoregression",
+			metric:           metric,
+			resultBuffer:     newTestResultBuffer(3, metrics.TestResult{Value: 60}),
+			predictionBuffer: ring.New(3),
+			expectedNone:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := calculatePredictedMetricValue(test.metric, test.resultBuffer, test.predictionBuffer, test.exogenous)
+			if test.expectedNone {
+				if next != test.predictionBuffer || next.Value != nil {
+					t.Errorf("calculatePredictedMetricValue() predicted %v, expected no prediction", next.Value)
+				}
+				return
+			}
+			if next != test.predictionBuffer.Next() {
+				t.Fatal("calculatePredictedMetricValue() did not advance prediction buffer")
+			}
+			prediction := next.Prev().Value.(metrics.TestResult)
+			if math.Abs(prediction.Value-test.expectedValue) > 1e-9 {
+				t.Errorf("predicted %f, expected %f", prediction.Value, test.expectedValue)
+			}
+			if prediction.UpperBoundTestPassed != test.expectedUpper || prediction.LowerBoundTestPassed {
+				t.Errorf("prediction bounds %+v, expected upper %t", prediction, test.expectedUpper)
+			}
+		})
+	}
+}
+
+func TestNewSarimaModel(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		AutoregresionDegree:                1,
+		AutoregressionCoefficients:         []string{"0.5"},
+		MovingAverageDegree:                1,
+		MovingAverageCoefficients:          []string{"0.4"},
+		DifferencingOrder:                  1,
+		SeasonalPeriod:                     2,
+		SeasonalAutoregressionDegree:       1,
+		SeasonalAutoregressionCoefficients: []string{"0.5"},
+		SeasonalMovingAverageDegree:        1,
+		SeasonalMovingAverageCoefficients:  []string{"0.5"},
+		SeasonalDifferencingOrder:          1,
+		Intercept:                          "1.5",
+	}
+	expected := sarimaModel{
+		autoregression: []float64{0.5, 0.5, -0.25},
+		movingAverage:  []float64{0.4, 0.5, 0.2},
+		differencing:   []float64{-1, -1, 1},
+		intercept:      1.5,
+	}
+	model := newSarimaModel(metric)
+	if !reflect.DeepEqual(model, expected) {
+		t.Errorf("newSarimaModel() = %+v, expected %+v", model, expected)
+	}
+	if length := model.historyLength(); length != 6 {
+		t.Errorf("historyLength() = %d, expected 6", length)
+	}
+	evaluator := newPredictiveEvaluator(metric)
+	if evaluator.historyBuffer.Len() != 6 || evaluator.predictionBuffer.Len() != 3 || evaluator.resultBuffer.Len() != 1 {
+		t.Errorf("buffers of evaluator have length %d, %d and %d", evaluator.historyBuffer.Len(), evaluator.predictionBuffer.Len(), evaluator.resultBuffer.Len())
+	}
+}
+
+func TestPredictiveEvaluatorSkipsInvalidResults(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		Name:                       "cpu",
+		NumOfTests:                 3,
+		ScaleDownValue:             "10",
+		ScaleUpValue:               "50",
+		AutoregresionDegree:        2,
+		AutoregressionCoefficients: []string{"0.5", "0.25"},
+	}
+	evaluator := newPredictiveEvaluator(metric)
+	exogenousRegressor := ExogenousRegressorScrapeResult{IsValid: true}
+	tests := []struct {
+		testResult                metrics.TestResult
+		expectedMetricUnavailable bool
+		expectedHistoryLength     int
+		expectedPredictedValue    float64
+		expectedHasPredictedValue bool
+	}{
+		{testResult: metrics.TestResult{IsValid: true, Value: 10}, expectedHistoryLength: 1},
+		{testResult: metrics.TestResult{}, expectedMetricUnavailable: true, expectedHistoryLength: 1},
+		{testResult: metrics.TestResult{IsValid: true, Value: 20}, expectedHistoryLength: 2, expectedPredictedValue: 12.5, expectedHasPredictedValue: true},
+	}
+	for i, test := range tests {
+		ae := evaluator.Evaluate(test.testResult, exogenousRegressor, time.Time{})
+		if ae.MetricUnavailable != test.expectedMetricUnavailable || ae.HistoryLength != test.expectedHistoryLength || ae.RequiredHistoryLength != 2 ||
+			ae.HasPredictedValue != test.expectedHasPredictedValue || ae.PredictedValue != test.expectedPredictedValue {
+			t.Errorf("Evaluate() of result %d = %+v, expected unavailable metric: %t, history %d of 2, predicted value %f",
+				i, ae, test.expectedMetricUnavailable, test.expectedHistoryLength, test.expectedPredictedValue)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"log"
+	"math"
+	"time"
+)
+
+// startQueuePipeline reads backlog and rates of queue by queue tester instead of aggregated scrapes
+func startQueuePipeline(metric scalingv1.AutoscalingDefinitionMetric, providers metrics.TargetProviders) (MetricPipeline, error) {
+	queueTestResultsChannel, err := metrics.MakeQueueTest(metric, providers.Clock)
+	if err != nil {
+		return MetricPipeline{}, err
+	}
+	return MetricPipeline{
+		TestInterval: queueTestResultsChannel.TestInterval,
+		Evaluation:   EvaluateAutoscalingQueue(queueTestResultsChannel, metric),
+	}, nil
+}
+
+func EvaluateAutoscalingQueue(
+	resultChannel metrics.QueueTestResultsChannel,
+	metric scalingv1.AutoscalingDefinitionMetric) AutoscaleEvaluationResult {
+
+	autoscaleEvaluationChannel := make(chan AutoscaleEvaluation)
+	closeEvaluationProcessChannel := make(chan bool)
+	clearBufferChannel := make(chan bool)
+	go func() {
+		targetDrainTime, err := time.ParseDuration(metric.TargetDrainTime)
+		if err != nil || targetDrainTime <= 0 {
+			targetDrainTime = 5 * time.Minute
+		}
+		for {
+			select {
+			case testResult := <-resultChannel.QueueTestResultsChannel:
+				if !testResult.IsValid {
+					autoscaleEvaluationChannel <- AutoscaleEvaluation{MetricUnavailable: true, Metric: metric}
+					continue
+				}
+				desiredReplicas, ok := calculateQueueDesiredReplicas(testResult, targetDrainTime)
+				if !ok {
+					log.Printf("Processing rate of metric %s must be positive, skipping evaluation", metric.Name)
+					continue
+				}
+				ae := AutoscaleEvaluation{
+					ScaleDown:          false,
+					ScaleUp:            false,
+					DesiredReplicas:    desiredReplicas,
+					HasDesiredReplicas: true,
+				}
+				ae.MetricValue, ae.HasMetricValue = testResult.Backlog, true
+				ae.Metric = metric
+				autoscaleEvaluationChannel <- ae
+			case <-closeEvaluationProcessChannel:
+				return
+			case <-clearBufferChannel:
+			}
+		}
+	}()
+	return AutoscaleEvaluationResult{
+		AutoscaleEvaluation:           autoscaleEvaluationChannel,
+		CloseEvaluationProcessChannel: closeEvaluationProcessChannel,
+		ClearBufferChannel:            clearBufferChannel,
+	}
+}
+
+// calculateQueueDesiredReplicas applies Little's law: the replicas have to absorb the arrival rate
+// and additionally drain the current backlog within targetDrainTime.
+func calculateQueueDesiredReplicas(testResult metrics.QueueTestResult, targetDrainTime time.Duration) (int, bool) {
+	if testResult.ProcessingRate <= 0 {
+		return 0, false
+	}
+	requiredRate := math.Max(testResult.ArrivalRate, 0) + math.Max(testResult.Backlog, 0)/targetDrainTime.Seconds()
+	desiredReplicas := int(math.Ceil(requiredRate / testResult.ProcessingRate))
+	log.Printf("Queue backlog: %f, arrival rate: %f, processing rate: %f, desired replicas: %d",
+		testResult.Backlog, testResult.ArrivalRate, testResult.ProcessingRate, desiredReplicas)
+	return desiredReplicas, true
+}
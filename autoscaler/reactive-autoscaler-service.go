@@ -2,55 +2,82 @@ package autoscaler
 
 import (
 	"container/ring"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/metrics"
-	"custom-hpa/model"
+	"custom-hpa/util"
 	"math"
 	"time"
 )
 
+type reactiveEvaluator struct {
+	metric                scalingv1.AutoscalingDefinitionMetric
+	resultBuffer          *ring.Ring
+	requiredPositiveTests int
+	panic                 *panicDetector
+}
+
 func EvaluateAutoscalingReactive(
 	resultChannel metrics.TestResultsChannel,
-	metric model.AutoscalingDefinitionMetric) AutoscaleEvaluationResult {
+	metric scalingv1.AutoscalingDefinitionMetric, clock util.Clock) AutoscaleEvaluationResult {
+	return startEvaluationProcess(newReactiveEvaluator(metric), resultChannel, nil, clock)
+}
 
-	autoscaleEvaluationChannel := make(chan AutoscaleEvaluation)
-	closeEvaluationProcessChannel := make(chan bool)
-	clearBufferChannel := make(chan bool)
-	go func() {
-		var resultBuffer = ring.New(metric.NumOfTests)
-		var requiredPositiveTests = int(math.Round(float64(metric.NumOfTests+1) / 2.0))
-		for {
-			select {
-			case testResult := <-resultChannel.TestResultsChannel:
-				resultBuffer.Value = testResult
-				resultBuffer = resultBuffer.Next()
-				ae := checkBuffer(resultBuffer, requiredPositiveTests)
-				ae.Metric = metric
-				autoscaleEvaluationChannel <- ae
-				resultBuffer.Value = nil
-			case <-closeEvaluationProcessChannel:
-				return
-			case <-clearBufferChannel:
-				clearBuffer(resultBuffer)
-			}
-		}
-	}()
-	return AutoscaleEvaluationResult{
-		AutoscaleEvaluation:           autoscaleEvaluationChannel,
-		CloseEvaluationProcessChannel: closeEvaluationProcessChannel,
-		ClearBufferChannel:            clearBufferChannel,
+func newReactiveEvaluator(metric scalingv1.AutoscalingDefinitionMetric) *reactiveEvaluator {
+	return &reactiveEvaluator{
+		metric:                metric,
+		resultBuffer:          ring.New(metric.NumOfTests),
+		requiredPositiveTests: int(math.Round(float64(metric.NumOfTests+1) / 2.0)),
+		panic:                 newPanicDetector(metric),
 	}
 }
 
-func fillDefinitionsDefaultValues(definition *model.AutoscalingDefinition) {
-	if definition.Spec.MinReplicas <= 0 {
+func (e *reactiveEvaluator) Evaluate(testResult metrics.TestResult, exogenousRegressor ExogenousRegressorScrapeResult, now time.Time) AutoscaleEvaluation {
+	e.resultBuffer.Value = testResult
+	e.resultBuffer = e.resultBuffer.Next()
+	ae := checkBuffer(e.resultBuffer, e.requiredPositiveTests)
+	ae.Metric = e.metric
+	ae.MetricUnavailable = !testResult.IsValid
+	ae.MetricValue, ae.HasMetricValue = testResult.Value, testResult.IsValid
+	ae.ScrapeCount, ae.NumOfTests = testResult.ScrapeCount, e.metric.NumOfTests
+	applyTotalValueScope(&ae, testResult, e.metric)
+	if e.panic != nil {
+		e.panic.update(testResult, &ae, now)
+	}
+	e.resultBuffer.Value = nil
+	return ae
+}
+
+func (e *reactiveEvaluator) Clear() {
+	clearBuffer(e.resultBuffer)
+}
+
+// FillDefinitionDefaultValues applies effective defaults of definition and all its metrics.
+// Defaults of definition and of metric algorithms are persisted into stored objects by defaulting webhook.
+func FillDefinitionDefaultValues(definition *scalingv1.AutoscalingDefinition) {
+	fillDefinitionDefaultValues(definition, metrics.FillEmptyMetricFields)
+}
+
+// FillDefinitionStoredDefaultValues fills defaults persisted by defaulting webhook, metrics get defaults of their algorithm only
+func FillDefinitionStoredDefaultValues(definition *scalingv1.AutoscalingDefinition) {
+	fillDefinitionDefaultValues(definition, metrics.FillRelevantMetricFields)
+}
+
+func fillDefinitionDefaultValues(definition *scalingv1.AutoscalingDefinition, fillMetricFields func(metric *scalingv1.AutoscalingDefinitionMetric)) {
+	if definition.Spec.MinReplicas <= 0 && !isActivationEnabled(definition.Spec) {
 		definition.Spec.MinReplicas = 1
 	}
+	if definition.Spec.MinReplicas < 0 {
+		definition.Spec.MinReplicas = 0
+	}
 	if definition.Spec.MaxReplicas <= 0 {
 		definition.Spec.MaxReplicas = 1
 	}
 	if definition.Spec.ScalingStep <= 0 {
 		definition.Spec.ScalingStep = 1
 	}
+	if definition.Spec.DecisionHistoryLimit <= 0 {
+		definition.Spec.DecisionHistoryLimit = DefaultDecisionHistoryLimit
+	}
 	if _, err := time.ParseDuration(definition.Spec.IntervalBetweenAutoscaling); len(definition.Spec.IntervalBetweenAutoscaling) <= 0 || err != nil {
 		definition.Spec.IntervalBetweenAutoscaling = "2m"
 	}
@@ -60,6 +87,18 @@ func fillDefinitionsDefaultValues(definition *model.AutoscalingDefinition) {
 	if len(definition.Spec.ScaleTarget.TargetType) <= 0 {
 		definition.Spec.ScaleTarget.TargetType = "deployment"
 	}
+	if isActivationEnabled(definition.Spec) {
+		fillActivationDefaultValues(&definition.Spec.ActivationMetric)
+	}
+	if isFallbackEnabled(definition.Spec) && definition.Spec.Fallback.FailureThreshold <= 0 {
+		definition.Spec.Fallback.FailureThreshold = 3
+	}
+	definitionMetrics := make([]scalingv1.AutoscalingDefinitionMetric, len(definition.Spec.Metrics))
+	copy(definitionMetrics, definition.Spec.Metrics)
+	for i := range definitionMetrics {
+		fillMetricFields(&definitionMetrics[i])
+	}
+	definition.Spec.Metrics = definitionMetrics
 }
 
 func checkBuffer(buffer *ring.Ring, requiredPositiveTests int) AutoscaleEvaluation {
@@ -83,8 +122,10 @@ func checkBuffer(buffer *ring.Ring, requiredPositiveTests int) AutoscaleEvaluati
 		}
 	})
 	var result = AutoscaleEvaluation{
-		ScaleDown: false,
-		ScaleUp:   false,
+		ScaleDown:       false,
+		ScaleUp:         false,
+		LowerBoundTests: scaleDownCounter,
+		UpperBoundTests: scaleUpCounter,
 	}
 	if scaleDownCounter >= requiredPositiveTests {
 		result.ScaleDown = true
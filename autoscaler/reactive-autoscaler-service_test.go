@@ -0,0 +1,62 @@
+package autoscaler
+
+import (
+	"container/ring"
+	"custom-hpa/metrics"
+	"testing"
+)
+
+func newTestResultBuffer(size int, results ...metrics.TestResult) *ring.Ring {
+	buffer := ring.New(size)
+	for _, result := range results {
+		buffer.Value = result
+		buffer = buffer.Next()
+	}
+	return buffer
+}
+
+func TestCheckBuffer(t *testing.T) {
+	lower := metrics.TestResult{LowerBoundTestPassed: true}
+	upper := metrics.TestResult{UpperBoundTestPassed: true}
+	neutral := metrics.TestResult{}
+	tests := []struct {
+		name                  string
+		buffer                *ring.Ring
+		requiredPositiveTests int
+		expected              AutoscaleEvaluation
+	}{
+		{
+			name:                  "buffer not filled",
+			buffer:                newTestResultBuffer(3, upper, upper),
+			requiredPositiveTests: 2,
+			expected:              AutoscaleEvaluation{},
+		},
+		{
+			name:                  "scale up",
+			buffer:                newTestResultBuffer(3, upper, neutral, upper),
+			requiredPositiveTests: 2,
+			expected:              AutoscaleEvaluation{ScaleUp: true, UpperBoundTests: 2},
+		},
+		{
+			name:                  "scale down",
+			buffer:                newTestResultBuffer(3, lower, lower, lower),
+			requiredPositiveTests: 3,
+			expected:              AutoscaleEvaluation{ScaleDown: true, LowerBoundTests: 3},
+		},
+		{
+			name:                  "not enough positive tests",
+			buffer:                newTestResultBuffer(3, lower, upper, neutral),
+			requiredPositiveTests: 2,
+			expected:              AutoscaleEvaluation{LowerBoundTests: 1, UpperBoundTests: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := checkBuffer(test.buffer, test.requiredPositiveTests)
+			if result.ScaleUp != test.expected.ScaleUp || result.ScaleDown != test.expected.ScaleDown ||
+				result.UpperBoundTests != test.expected.UpperBoundTests || result.LowerBoundTests != test.expected.LowerBoundTests {
+				t.Errorf("checkBuffer() = %+v, expected %+v", result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/clients"
+	"k8s.io/client-go/kubernetes"
+	"log"
+	"strings"
+	"time"
+)
+
+type rolloutGuard struct {
+	policy           string
+	postRolloutDelay time.Duration
+	isInProgress     bool
+	finishedAt       time.Time
+}
+
+type RolloutRestriction struct {
+	IsDeferred       bool
+	IsCapped         bool
+	ScaleDownBlocked bool
+}
+
+func newRolloutGuard(rollout scalingv1.AutoscalingDefinitionRollout) *rolloutGuard {
+	postRolloutDelay, err := time.ParseDuration(rollout.PostRolloutDelay)
+	if err != nil {
+		postRolloutDelay = 0
+	}
+	return &rolloutGuard{
+		policy:           strings.ToUpper(rollout.Policy),
+		postRolloutDelay: postRolloutDelay,
+	}
+}
+
+func (g *rolloutGuard) isEnabled() bool {
+	return g.policy == "DEFER" || g.policy == "CAP" || g.postRolloutDelay > 0
+}
+
+// check inspects rollout of target and returns restrictions applied to autoscaling decision
+func (g *rolloutGuard) check(client *kubernetes.Clientset, target scalingv1.AutoscalingDefinitionScaleTarget, now time.Time) RolloutRestriction {
+	if !g.isEnabled() {
+		return RolloutRestriction{}
+	}
+	isInProgress, err := clients.IsRolloutInProgress(client, target)
+	if err != nil {
+		log.Printf("Rollout status error: %s", err.Error())
+		return RolloutRestriction{}
+	}
+	if g.isInProgress && !isInProgress {
+		log.Printf("Rollout of %s finished", target.MatchLabel)
+		g.finishedAt = now
+	}
+	g.isInProgress = isInProgress
+	if isInProgress {
+		log.Printf("Rollout of %s in progress, policy: %s", target.MatchLabel, g.policy)
+		return RolloutRestriction{
+			IsDeferred:       g.policy == "DEFER",
+			IsCapped:         g.policy == "CAP",
+			ScaleDownBlocked: g.policy == "CAP",
+		}
+	}
+	return RolloutRestriction{
+		ScaleDownBlocked: !g.finishedAt.IsZero() && now.Sub(g.finishedAt) < g.postRolloutDelay,
+	}
+}
@@ -0,0 +1,66 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/clients"
+	"custom-hpa/exporter"
+	"custom-hpa/pkg/client/clientset/versioned"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	"k8s.io/client-go/kubernetes"
+	"log"
+	"strings"
+)
+
+type targetScaler struct {
+	client           *kubernetes.Clientset
+	definitionClient versioned.Interface
+	definition       scalingv1.AutoscalingDefinition
+	recommendOnly    bool
+}
+
+func newTargetScaler(client *kubernetes.Clientset, definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, options AutoscalerOptions) *targetScaler {
+	return &targetScaler{
+		client:           client,
+		definitionClient: definitionClient,
+		definition:       definition,
+		recommendOnly:    options.DryRun || strings.ToUpper(definition.Spec.Mode) == "RECOMMEND",
+	}
+}
+
+// scale updates target to replicas, in recommendation mode replicas are only recorded.
+// Every decision is stored in status, so that it can be inspected by kubectl-customhpa.
+func (s *targetScaler) scale(deploymentScale *v1beta1.Scale, replicas int, decision scalingv1.AutoscalingDefinitionDecision) (*v1beta1.Scale, error) {
+	decision.FromReplicas = int(deploymentScale.Spec.Replicas)
+	decision.ToReplicas = replicas
+	deploymentScale.Spec.Replicas = int32(replicas)
+	reason := decision.Reason
+	labels := map[string]string{"definition": s.definition.Name, "namespace": s.definition.Namespace}
+	if !s.recommendOnly {
+		exporter.SetGauge("custom_hpa_desired_replicas", "Number of replicas set by autoscaler", labels, float64(replicas))
+		scale, err := clients.ScaleObject(s.client, s.definition.Spec.ScaleTarget, deploymentScale)
+		if err == nil {
+			recordEvent(s.client, s.definition, corev1.EventTypeNormal, "Scaled",
+				fmt.Sprintf("Scaled %s from %d to %d replicas based on: %s", s.definition.Spec.ScaleTarget.MatchLabel, decision.FromReplicas, replicas, reason))
+			updateDefinitionStatus(s.definitionClient, s.definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+				status.LastScaleTime = decision.Time
+				status.LastDecision = decision
+				appendDecision(status, decision, s.definition.Spec.DecisionHistoryLimit)
+			})
+		}
+		return scale, err
+	}
+	message := fmt.Sprintf("Recommended %d replicas for %s based on: %s", replicas, s.definition.Spec.ScaleTarget.MatchLabel, reason)
+	log.Print(message)
+	exporter.SetGauge("custom_hpa_recommended_replicas", "Number of replicas recommended by autoscaler in recommendation mode", labels, float64(replicas))
+	recordEvent(s.client, s.definition, corev1.EventTypeNormal, "Recommendation", message)
+	updateDefinitionStatus(s.definitionClient, s.definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+		status.RecommendedReplicas = replicas
+		status.LastRecommendationTime = decision.Time
+		status.LastDecision = decision
+		appendDecision(status, decision, s.definition.Spec.DecisionHistoryLimit)
+		status.Message = message
+	})
+	return deploymentScale, nil
+}
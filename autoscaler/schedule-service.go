@@ -0,0 +1,90 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/util"
+	"log"
+	"time"
+)
+
+type parsedSchedule struct {
+	schedule scalingv1.AutoscalingDefinitionSchedule
+	cron     *util.CronSchedule
+	location *time.Location
+	duration time.Duration
+}
+
+type ReplicaBounds struct {
+	MinReplicas  int
+	MaxReplicas  int
+	Replicas     int
+	IsPinned     bool
+	ScheduleName string
+}
+
+func parseSchedules(schedules []scalingv1.AutoscalingDefinitionSchedule) []parsedSchedule {
+	var result []parsedSchedule
+	for _, schedule := range schedules {
+		cron, err := util.ParseCron(schedule.Cron)
+		if err != nil {
+			log.Printf("Schedule %s error: %s", schedule.Name, err.Error())
+			continue
+		}
+		duration, err := time.ParseDuration(schedule.Duration)
+		if err != nil {
+			log.Printf("Schedule %s duration error: %s", schedule.Name, err.Error())
+			continue
+		}
+		location := time.UTC
+		if len(schedule.Timezone) > 0 {
+			location, err = time.LoadLocation(schedule.Timezone)
+			if err != nil {
+				log.Printf("Schedule %s timezone error: %s", schedule.Name, err.Error())
+				continue
+			}
+		}
+		result = append(result, parsedSchedule{
+			schedule: schedule,
+			cron:     cron,
+			location: location,
+			duration: duration,
+		})
+	}
+	return result
+}
+
+// currentReplicaBounds merges all schedules active at now into spec bounds.
+// The highest floor, the highest ceiling and the highest pinned replica count win.
+func currentReplicaBounds(spec scalingv1.AutoscalingDefinitionSpec, schedules []parsedSchedule, now time.Time) ReplicaBounds {
+	bounds := ReplicaBounds{
+		MinReplicas: spec.MinReplicas,
+		MaxReplicas: spec.MaxReplicas,
+	}
+	var minOverridden, maxOverridden = false, false
+	for _, ps := range schedules {
+		if !ps.cron.IsActive(now.In(ps.location), ps.duration) {
+			continue
+		}
+		bounds.ScheduleName = ps.schedule.Name
+		if ps.schedule.MinReplicas > 0 && (!minOverridden || ps.schedule.MinReplicas > bounds.MinReplicas) {
+			bounds.MinReplicas = ps.schedule.MinReplicas
+			minOverridden = true
+		}
+		if ps.schedule.MaxReplicas > 0 && (!maxOverridden || ps.schedule.MaxReplicas > bounds.MaxReplicas) {
+			bounds.MaxReplicas = ps.schedule.MaxReplicas
+			maxOverridden = true
+		}
+		if ps.schedule.Replicas > 0 && ps.schedule.Replicas > bounds.Replicas {
+			bounds.Replicas = ps.schedule.Replicas
+			bounds.IsPinned = true
+		}
+	}
+	if bounds.MaxReplicas < bounds.MinReplicas {
+		bounds.MaxReplicas = bounds.MinReplicas
+	}
+	if bounds.IsPinned {
+		bounds.MinReplicas = bounds.Replicas
+		bounds.MaxReplicas = bounds.Replicas
+	}
+	return bounds
+}
@@ -0,0 +1,285 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"custom-hpa/util"
+	"errors"
+	"fmt"
+	model2 "github.com/prometheus/common/model"
+	"math"
+	"sort"
+	"time"
+)
+
+type SimulationSample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// SimulationOptions configure simulated target. When PodCapacity is set, series holds total load, which is split among ready pods,
+// otherwise series holds metric values as scraped. SloValue is value per ready pod above which scrape interval violates SLO,
+// it defaults to PodCapacity. ExogenousSeries holds series of exogenous regressors by their name, series without name is used
+// by metric with single regressor.
+type SimulationOptions struct {
+	MetricName      string
+	InitialReplicas int
+	PodStartupDelay time.Duration
+	PodCapacity     float64
+	SloValue        float64
+	ExogenousSeries map[string][]SimulationSample
+}
+
+type SimulationStep struct {
+	Time           time.Time `json:"time"`
+	Load           float64   `json:"load"`
+	MetricValue    float64   `json:"metricValue"`
+	PredictedValue *float64  `json:"predictedValue,omitempty"`
+	Replicas       int       `json:"replicas"`
+	ReadyReplicas  int       `json:"readyReplicas"`
+	Scaled         bool      `json:"scaled"`
+	Reason         string    `json:"reason"`
+}
+
+type SimulationResult struct {
+	Metric              string                                    `json:"metric"`
+	Timeline            []SimulationStep                          `json:"timeline"`
+	Decisions           []scalingv1.AutoscalingDefinitionDecision `json:"decisions"`
+	SloViolations       int                                       `json:"sloViolations"`
+	SloViolationMinutes float64                                   `json:"sloViolationMinutes"`
+	ReplicaMinutes      float64                                   `json:"replicaMinutes"`
+}
+
+type simulatedTarget struct {
+	startupDelay time.Duration
+	pods         []time.Time
+}
+
+// Simulate replays recorded series through scrape, test, evaluation and scaling steps of autoscaler against fake clock,
+// which advances by scrape interval of metric from the first to the last sample. Activation, fallback, rollout and control
+// annotations are not simulated.
+func Simulate(definition scalingv1.AutoscalingDefinition, series []SimulationSample, options SimulationOptions) (SimulationResult, error) {
+	FillDefinitionDefaultValues(&definition)
+	metric, err := findSimulatedMetric(definition.Spec, options.MetricName)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	if len(series) <= 0 {
+		return SimulationResult{}, errors.New("series has no samples")
+	}
+	var exogenousCollector *exogenousRegressorCollector
+	var exogenousSeries [][]SimulationSample
+	if RequiresExogenousRegressor(metric) {
+		if exogenousCollector, err = newExogenousRegressorCollector(metric); err != nil {
+			return SimulationResult{}, err
+		}
+		if exogenousSeries, err = findExogenousSeries(metric, exogenousCollector.regressors, options.ExogenousSeries); err != nil {
+			return SimulationResult{}, err
+		}
+	}
+	scrapeInterval, err := time.ParseDuration(metric.ScrapeInterval)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	testInterval, err := time.ParseDuration(metric.TestInterval)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	intervalBetweenAutoscaling, err := time.ParseDuration(definition.Spec.IntervalBetweenAutoscaling)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	scrapesPerTest := int(testInterval / scrapeInterval)
+	if scrapesPerTest <= 0 {
+		scrapesPerTest = 1
+	}
+	sortSamples(series)
+	sloValue := options.SloValue
+	if sloValue <= 0 {
+		sloValue = options.PodCapacity
+	}
+	initialReplicas := options.InitialReplicas
+	if initialReplicas <= 0 {
+		initialReplicas = int(math.Max(1, float64(definition.Spec.MinReplicas)))
+	}
+
+	start, end := series[0].Time, series[len(series)-1].Time
+	target := newSimulatedTarget(initialReplicas, options.PodStartupDelay, start)
+	evaluator := NewEvaluator(metric)
+	schedules := parseSchedules(definition.Spec.Schedules)
+	result := SimulationResult{Metric: metric.Name}
+	var scrapes []metrics.MetricValidateResult
+	var clock = util.NewFakeClock(start)
+	var cooldown = newCooldown(clock, intervalBetweenAutoscaling)
+	var scrapesCounter = 0
+	for ; !clock.Now().After(end); clock.Advance(scrapeInterval) {
+		now := clock.Now()
+		load := sampleAt(series, now)
+		replicas, readyReplicas := target.replicas(), target.readyReplicas(now)
+		value, valuePerPod := simulatedMetricValue(load, readyReplicas, metric, options.PodCapacity)
+		result.ReplicaMinutes += float64(replicas) * scrapeInterval.Minutes()
+		if sloValue > 0 && (valuePerPod > sloValue || (readyReplicas <= 0 && load > 0)) {
+			result.SloViolations++
+			result.SloViolationMinutes += scrapeInterval.Minutes()
+		}
+
+		timestamp := model2.TimeFromUnixNano(now.UnixNano())
+		scrape, err := metrics.EvaluateScrapedValue(&model2.Scalar{Value: model2.SampleValue(value), Timestamp: timestamp}, metric, nil, readyReplicas)
+		if err == nil && scrape.IsMetricValid {
+			scrapes = append(scrapes, scrape)
+		}
+		for i, series := range exogenousSeries {
+			exogenousCollector.add(i, []float64{sampleAt(series, now)})
+		}
+		scrapesCounter++
+		if scrapesCounter < scrapesPerTest {
+			continue
+		}
+		scrapesCounter = 0
+
+		testResult := metrics.TestScrapes(scrapes, metric)
+		scrapes = nil
+		var exogenousRegressor ExogenousRegressorScrapeResult
+		if exogenousCollector != nil {
+			exogenousRegressor = exogenousCollector.result(scrapesPerTest)
+		}
+		ae := evaluator.Evaluate(testResult, exogenousRegressor, now)
+		step := SimulationStep{Time: now, Load: load, MetricValue: testResult.Value, Replicas: replicas, ReadyReplicas: readyReplicas}
+		if ae.HasPredictedValue {
+			predictedValue := ae.PredictedValue
+			step.PredictedValue = &predictedValue
+		}
+		var inputs []scalingv1.AutoscalingDefinitionDecisionInput
+		if ae.HasMetricValue {
+			inputs = append(inputs, newDecisionInput(ae))
+		}
+
+		bounds := currentReplicaBounds(definition.Spec, schedules, now)
+		if bounds.MinReplicas <= 0 {
+			bounds.MinReplicas = 1
+		}
+		if bounds.IsPinned {
+			ae = AutoscaleEvaluation{DesiredReplicas: bounds.Replicas, HasDesiredReplicas: true, Metric: ae.Metric}
+		}
+		ae, panicScaling := applyPanicScaling(ae, replicas, bounds)
+		if cooldown.isActive() && !panicScaling {
+			step.Reason = "blocked by intervalBetweenAutoscaling"
+		} else {
+			plan := planScaling(ae, replicas, bounds, ScalingControl{}, RolloutRestriction{}, definition.Spec.ScalingStep, panicScaling)
+			step.Reason = plan.Reason
+			if plan.Replicas != replicas {
+				decision := newDecision(now, metric.Name, plan.Reason, inputs, plan.Policies...)
+				decision.FromReplicas, decision.ToReplicas = replicas, plan.Replicas
+				result.Decisions = append(result.Decisions, decision)
+				target.scale(plan.Replicas, now)
+				evaluator.Clear()
+				cooldown.start()
+				step.Replicas, step.Scaled = plan.Replicas, true
+			}
+		}
+		result.Timeline = append(result.Timeline, step)
+	}
+	return result, nil
+}
+
+func findSimulatedMetric(spec scalingv1.AutoscalingDefinitionSpec, name string) (scalingv1.AutoscalingDefinitionMetric, error) {
+	for _, metric := range spec.Metrics {
+		if metric.Name != name && len(name) > 0 {
+			continue
+		}
+		if !IsSimulated(metric) {
+			return metric, errors.New(fmt.Sprintf("metric %s with algorithm %s cannot be simulated", metric.Name, metric.Algorithm))
+		}
+		return metric, nil
+	}
+	if len(name) > 0 {
+		return scalingv1.AutoscalingDefinitionMetric{}, errors.New(fmt.Sprintf("metric %s not found in definition", name))
+	}
+	return scalingv1.AutoscalingDefinitionMetric{}, errors.New("definition has no metrics")
+}
+
+// findExogenousSeries returns sorted series of every exogenous regressor ordered as regressors
+func findExogenousSeries(metric scalingv1.AutoscalingDefinitionMetric, regressors []scalingv1.AutoscalingDefinitionExogenousRegressor,
+	exogenousSeries map[string][]SimulationSample) ([][]SimulationSample, error) {
+	var result [][]SimulationSample
+	for _, regressor := range regressors {
+		series, ok := exogenousSeries[regressor.Name]
+		if !ok && len(regressors) == 1 {
+			series = exogenousSeries[""]
+		}
+		if len(series) <= 0 {
+			return nil, errors.New(fmt.Sprintf("metric %s with algorithm %s requires series of exogenous regressor %s", metric.Name, metric.Algorithm, regressor.Name))
+		}
+		sortSamples(series)
+		result = append(result, series)
+	}
+	return result, nil
+}
+
+// simulatedMetricValue returns value scraped from simulated target and value per ready pod used for SLO.
+func simulatedMetricValue(load float64, readyReplicas int, metric scalingv1.AutoscalingDefinitionMetric, podCapacity float64) (float64, float64) {
+	if podCapacity <= 0 {
+		return load, load
+	}
+	valuePerPod := load
+	if readyReplicas > 0 {
+		valuePerPod = load / float64(readyReplicas)
+	}
+	if metrics.IsTotalValueScope(metric) {
+		return load, valuePerPod
+	}
+	return valuePerPod, valuePerPod
+}
+
+// sampleAt returns value of the latest sample not after t, series has to be sorted.
+func sampleAt(series []SimulationSample, t time.Time) float64 {
+	i := sort.Search(len(series), func(i int) bool {
+		return series[i].Time.After(t)
+	})
+	if i <= 0 {
+		if len(series) > 0 {
+			return series[0].Value
+		}
+		return 0
+	}
+	return series[i-1].Value
+}
+
+func sortSamples(series []SimulationSample) {
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Time.Before(series[j].Time)
+	})
+}
+
+// newSimulatedTarget starts with initial replicas already ready.
+func newSimulatedTarget(replicas int, startupDelay time.Duration, now time.Time) *simulatedTarget {
+	target := &simulatedTarget{startupDelay: startupDelay}
+	for i := 0; i < replicas; i++ {
+		target.pods = append(target.pods, now.Add(-startupDelay))
+	}
+	return target
+}
+
+func (t *simulatedTarget) replicas() int {
+	return len(t.pods)
+}
+
+func (t *simulatedTarget) readyReplicas(now time.Time) int {
+	ready := 0
+	for _, startedAt := range t.pods {
+		if !now.Before(startedAt.Add(t.startupDelay)) {
+			ready++
+		}
+	}
+	return ready
+}
+
+// scale starts new pods at now, scaling down removes the youngest pods first.
+func (t *simulatedTarget) scale(replicas int, now time.Time) {
+	for len(t.pods) < replicas {
+		t.pods = append(t.pods, now)
+	}
+	if len(t.pods) > replicas {
+		t.pods = t.pods[:replicas]
+	}
+}
@@ -0,0 +1,85 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/clients"
+	"custom-hpa/pkg/client/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"log"
+	"strconv"
+	"time"
+)
+
+func updateDefinitionStatus(definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, update func(status *scalingv1.AutoscalingDefinitionStatus)) {
+	current, err := definitionClient.ScalingV1().AutoscalingDefinitions(definition.Namespace).Get(definition.Name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Printf("Status update error: %s", err.Error())
+		return
+	}
+	update(&current.Status)
+	_, err = definitionClient.ScalingV1().AutoscalingDefinitions(definition.Namespace).UpdateStatus(current)
+	if err != nil {
+		log.Printf("Status update error: %s", err.Error())
+	}
+}
+
+func reportMetricStatus(definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, ae AutoscaleEvaluation) {
+	if !ae.HasMetricValue {
+		return
+	}
+	metricStatus := scalingv1.AutoscalingDefinitionMetricStatus{
+		Name:                  ae.Metric.Name,
+		Value:                 strconv.FormatFloat(ae.MetricValue, 'f', -1, 64),
+		HistoryLength:         ae.HistoryLength,
+		RequiredHistoryLength: ae.RequiredHistoryLength,
+		LastUpdateTime:        meta_v1.Now(),
+	}
+	if ae.HasPredictedValue {
+		metricStatus.PredictedValue = strconv.FormatFloat(ae.PredictedValue, 'f', -1, 64)
+	}
+	updateDefinitionStatus(definitionClient, definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+		for i := range status.Metrics {
+			if status.Metrics[i].Name == metricStatus.Name {
+				status.Metrics[i] = metricStatus
+				return
+			}
+		}
+		status.Metrics = append(status.Metrics, metricStatus)
+	})
+}
+
+func reportCooldown(definitionClient versioned.Interface, definition scalingv1.AutoscalingDefinition, cooldownUntil time.Time) {
+	updateDefinitionStatus(definitionClient, definition, func(status *scalingv1.AutoscalingDefinitionStatus) {
+		status.CooldownUntil = meta_v1.NewTime(cooldownUntil)
+	})
+}
+
+func setStatusCondition(status *scalingv1.AutoscalingDefinitionStatus, conditionType string, conditionStatus corev1.ConditionStatus, reason string, message string) {
+	for i, condition := range status.Conditions {
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status != string(conditionStatus) {
+			status.Conditions[i].LastTransitionTime = meta_v1.Now()
+		}
+		status.Conditions[i].Status = string(conditionStatus)
+		status.Conditions[i].Reason = reason
+		status.Conditions[i].Message = message
+		return
+	}
+	status.Conditions = append(status.Conditions, scalingv1.AutoscalingDefinitionCondition{
+		Type:               conditionType,
+		Status:             string(conditionStatus),
+		LastTransitionTime: meta_v1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func recordEvent(client *kubernetes.Clientset, definition scalingv1.AutoscalingDefinition, eventType string, reason string, message string) {
+	if _, err := clients.CreateEvent(client, definition, eventType, reason, message); err != nil {
+		log.Printf("Event error: %s", err.Error())
+	}
+}
@@ -0,0 +1,130 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/clients"
+	"custom-hpa/metrics"
+	"custom-hpa/util"
+	"errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const targetStateCacheDuration = 15 * time.Second
+
+func isPodAwarenessEnabled(spec scalingv1.AutoscalingDefinitionSpec) bool {
+	return len(spec.InitializationPeriod) > 0 || len(spec.ReadinessDelay) > 0
+}
+
+// newPodStateProvider returns cached provider of target pod states, or nil when pod awareness is disabled
+func newPodStateProvider(client *kubernetes.Clientset, definition scalingv1.AutoscalingDefinition, clock util.Clock) metrics.PodStateProvider {
+	if !isPodAwarenessEnabled(definition.Spec) {
+		return nil
+	}
+	FillDefinitionDefaultValues(&definition)
+	initializationPeriod, _ := time.ParseDuration(definition.Spec.InitializationPeriod)
+	readinessDelay, _ := time.ParseDuration(definition.Spec.ReadinessDelay)
+	var mutex sync.Mutex
+	var cachedStates map[string]metrics.PodState
+	var cachedAt time.Time
+	return func() (map[string]metrics.PodState, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		now := clock.Now()
+		if cachedStates != nil && now.Sub(cachedAt) < targetStateCacheDuration {
+			return cachedStates, nil
+		}
+		pods, err := clients.GetTargetPods(client, definition.Spec.ScaleTarget)
+		if err != nil {
+			return nil, err
+		}
+		cachedStates = calculatePodStates(pods, now, initializationPeriod, readinessDelay)
+		cachedAt = now
+		return cachedStates, nil
+	}
+}
+
+// calculatePodStates ignores pods, which are not ready, still initializing or became ready within readiness delay.
+// Terminating and finished pods are not taken into account at all.
+func calculatePodStates(pods []corev1.Pod, now time.Time, initializationPeriod time.Duration, readinessDelay time.Duration) map[string]metrics.PodState {
+	result := make(map[string]metrics.PodState)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		var isReady = false
+		var readySince time.Time
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady {
+				isReady = condition.Status == corev1.ConditionTrue
+				readySince = condition.LastTransitionTime.Time
+			}
+		}
+		isInitializing := pod.Status.StartTime != nil && now.Sub(pod.Status.StartTime.Time) < initializationPeriod
+		isWarmingUp := isReady && now.Sub(readySince) < readinessDelay
+		result[pod.Name] = metrics.PodState{
+			IsIgnored: !isReady || isInitializing || isWarmingUp,
+		}
+	}
+	return result
+}
+
+// newReplicaCountProvider returns cached provider of current target replicas, or nil when no metric requires replicas
+func newReplicaCountProvider(client *kubernetes.Clientset, definition scalingv1.AutoscalingDefinition, clock util.Clock) metrics.ReplicaCountProvider {
+	var isRequired = false
+	for _, metric := range definition.Spec.Metrics {
+		isRequired = isRequired || metrics.RequiresReplicaCount(metric)
+	}
+	if !isRequired {
+		return nil
+	}
+	FillDefinitionDefaultValues(&definition)
+	var mutex sync.Mutex
+	var cachedReplicas int
+	var cachedAt time.Time
+	return func() (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		now := clock.Now()
+		if !cachedAt.IsZero() && now.Sub(cachedAt) < targetStateCacheDuration {
+			return cachedReplicas, nil
+		}
+		scale, err := clients.GetScale(client, definition.Spec.ScaleTarget)
+		if err != nil {
+			return 0, err
+		}
+		if scale == nil {
+			return 0, errors.New("target scale not found")
+		}
+		cachedReplicas = int(scale.Status.Replicas)
+		cachedAt = now
+		return cachedReplicas, nil
+	}
+}
+
+// applyTotalValueScope sizes replicas directly from total metric value, so that value per replica reaches midpoint between
+// scale down and scale up value and the next test neither scales up nor down again. Step based scaling is kept,
+// when calculated replicas contradict the evaluation or default algorithm aggregates no total value.
+func applyTotalValueScope(ae *AutoscaleEvaluation, testResult metrics.TestResult, metric scalingv1.AutoscalingDefinitionMetric) {
+	if !metrics.IsTotalValueScope(metric) || metrics.IsDefaultAlgorithm(metric.Algorithm) || !testResult.IsValid || testResult.Replicas <= 0 || (!ae.ScaleUp && !ae.ScaleDown) {
+		return
+	}
+	scaleUpValue, err := strconv.ParseFloat(metric.ScaleUpValue, 64)
+	if err != nil || scaleUpValue <= 0 {
+		return
+	}
+	scaleDownValue, err := strconv.ParseFloat(metric.ScaleDownValue, 64)
+	if err != nil || scaleDownValue < 0 || scaleDownValue >= scaleUpValue {
+		scaleDownValue = 0
+	}
+	targetValue := (scaleDownValue + scaleUpValue) / 2
+	desiredReplicas := int(math.Ceil(testResult.TotalValue / targetValue))
+	if (ae.ScaleUp && desiredReplicas > testResult.Replicas) || (ae.ScaleDown && desiredReplicas < testResult.Replicas) {
+		ae.DesiredReplicas = desiredReplicas
+		ae.HasDesiredReplicas = true
+	}
+}
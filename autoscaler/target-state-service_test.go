@@ -0,0 +1,64 @@
+package autoscaler
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"testing"
+)
+
+func TestApplyTotalValueScope(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{Algorithm: "mean", ValueScope: "total", ScaleDownValue: "20", ScaleUpValue: "80"}
+	tests := []struct {
+		name             string
+		algorithm        string
+		evaluation       AutoscaleEvaluation
+		testResult       metrics.TestResult
+		expectedReplicas int
+	}{
+		{
+			name:             "scale up to midpoint",
+			evaluation:       AutoscaleEvaluation{ScaleUp: true},
+			testResult:       metrics.TestResult{IsValid: true, Replicas: 2, TotalValue: 180},
+			expectedReplicas: 4,
+		},
+		{
+			name:             "scale down to midpoint",
+			evaluation:       AutoscaleEvaluation{ScaleDown: true},
+			testResult:       metrics.TestResult{IsValid: true, Replicas: 10, TotalValue: 150},
+			expectedReplicas: 3,
+		},
+		{
+			name:       "contradicting evaluation keeps step scaling",
+			evaluation: AutoscaleEvaluation{ScaleDown: true},
+			testResult: metrics.TestResult{IsValid: true, Replicas: 2, TotalValue: 150},
+		},
+		{
+			name:       "no scaling requested",
+			evaluation: AutoscaleEvaluation{},
+			testResult: metrics.TestResult{IsValid: true, Replicas: 2, TotalValue: 500},
+		},
+		{
+			name:       "default algorithm aggregates no total value",
+			algorithm:  "default",
+			evaluation: AutoscaleEvaluation{ScaleDown: true},
+			testResult: metrics.TestResult{IsValid: true, Replicas: 10, TotalValue: 0},
+		},
+	}
+	for _, test := range tests {
+		ae := test.evaluation
+		metric := metric
+		if len(test.algorithm) > 0 {
+			metric.Algorithm = test.algorithm
+		}
+		applyTotalValueScope(&ae, test.testResult, metric)
+		if ae.HasDesiredReplicas != (test.expectedReplicas > 0) || ae.DesiredReplicas != test.expectedReplicas {
+			t.Errorf("%s: desired replicas %d (%t), expected %d", test.name, ae.DesiredReplicas, ae.HasDesiredReplicas, test.expectedReplicas)
+		}
+		if ae.HasDesiredReplicas {
+			lower, upper := metrics.TestSingleValueBounds(metric, test.testResult.TotalValue/float64(ae.DesiredReplicas))
+			if lower || upper {
+				t.Errorf("%s: value per replica after scaling passes bound tests", test.name)
+			}
+		}
+	}
+}
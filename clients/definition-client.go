@@ -0,0 +1,42 @@
+package clients
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/pkg/client/clientset/versioned"
+	"encoding/json"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sort"
+)
+
+// SetDefinitionAnnotation sets annotation of stored definition, annotation is removed when value is nil
+func SetDefinitionAnnotation(definitionClient versioned.Interface, namespace string, name string, annotation string, value *string) (*scalingv1.AutoscalingDefinition, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{annotation: value},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return definitionClient.ScalingV1().AutoscalingDefinitions(namespace).Patch(name, types.MergePatchType, patch)
+}
+
+// ListDefinitionEvents returns events recorded for definition ordered from the oldest one
+func ListDefinitionEvents(client *kubernetes.Clientset, definition scalingv1.AutoscalingDefinition) ([]corev1.Event, error) {
+	selector := fields.Set{
+		"involvedObject.kind": "AutoscalingDefinition",
+		"involvedObject.name": definition.Name,
+	}
+	events, err := client.CoreV1().Events(definition.Namespace).List(v1.ListOptions{FieldSelector: selector.AsSelector().String()})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+	return events.Items, nil
+}
@@ -0,0 +1,34 @@
+package clients
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func CreateEvent(client *kubernetes.Clientset, definition scalingv1.AutoscalingDefinition, eventType string, reason string, message string) (*corev1.Event, error) {
+	now := v1.Now()
+	event := &corev1.Event{
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: definition.Name + ".",
+			Namespace:    definition.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      scalingv1.SchemeGroupVersion.String(),
+			Kind:            "AutoscalingDefinition",
+			Name:            definition.Name,
+			Namespace:       definition.Namespace,
+			UID:             definition.UID,
+			ResourceVersion: definition.ResourceVersion,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Source:         corev1.EventSource{Component: "custom-hpa"},
+	}
+	return client.CoreV1().Events(definition.Namespace).Create(event)
+}
@@ -1,7 +1,7 @@
 package clients
 
 import (
-	"custom-hpa/model"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
@@ -36,7 +36,7 @@ func getNamespace(name string, client *kubernetes.Clientset) (vpcs *corev1.Names
 	return namespace, nil
 }
 
-func GetScale(client *kubernetes.Clientset, target model.AutoscalingDefinitionScaleTarget) (*v1beta1.Scale, error) {
+func GetScale(client *kubernetes.Clientset, target scalingv1.AutoscalingDefinitionScaleTarget) (*v1beta1.Scale, error) {
 	set := labels.Set{target.LabelName: target.MatchLabel}
 	if target.TargetType == "deployment" {
 		deployments, err := client.ExtensionsV1beta1().Deployments(target.MatchNamespace).List(v1.ListOptions{LabelSelector: set.AsSelector().String()})
@@ -60,7 +60,7 @@ func GetScale(client *kubernetes.Clientset, target model.AutoscalingDefinitionSc
 	return nil, errors.New("not recognized target type")
 }
 
-func ScaleObject(client *kubernetes.Clientset, target model.AutoscalingDefinitionScaleTarget, scale *v1beta1.Scale) (*v1beta1.Scale, error) {
+func ScaleObject(client *kubernetes.Clientset, target scalingv1.AutoscalingDefinitionScaleTarget, scale *v1beta1.Scale) (*v1beta1.Scale, error) {
 	set := labels.Set{target.LabelName: target.MatchLabel}
 	if target.TargetType == "deployment" {
 		deployments, err := client.ExtensionsV1beta1().Deployments(target.MatchNamespace).List(v1.ListOptions{LabelSelector: set.AsSelector().String()})
@@ -83,3 +83,71 @@ func ScaleObject(client *kubernetes.Clientset, target model.AutoscalingDefinitio
 	}
 	return nil, errors.New("not recognized target type")
 }
+
+// IsRolloutInProgress reports whether deployment target is in the middle of rollout, replicasets are never rolled out
+func IsRolloutInProgress(client *kubernetes.Clientset, target scalingv1.AutoscalingDefinitionScaleTarget) (bool, error) {
+	if target.TargetType != "deployment" {
+		return false, nil
+	}
+	set := labels.Set{target.LabelName: target.MatchLabel}
+	deployments, err := client.ExtensionsV1beta1().Deployments(target.MatchNamespace).List(v1.ListOptions{LabelSelector: set.AsSelector().String()})
+	if err != nil {
+		return false, err
+	}
+	if deployments.Items == nil || len(deployments.Items) <= 0 {
+		return false, errors.New("target deployment not found")
+	}
+	return isRollingOut(deployments.Items[0]), nil
+}
+
+// isRollingOut detects rollout from spec generation, updated replicas and Progressing condition only. Available and old
+// replicas are not compared, since they differ also after scaling of deployment, which is not a rollout.
+func isRollingOut(deployment v1beta1.Deployment) bool {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return true
+	}
+	if deployment.Spec.Replicas != nil && deployment.Status.UpdatedReplicas < *deployment.Spec.Replicas {
+		return true
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == v1beta1.DeploymentProgressing && condition.Status == corev1.ConditionTrue && condition.Reason != "NewReplicaSetAvailable" {
+			return true
+		}
+	}
+	return false
+}
+
+func GetTargetPods(client *kubernetes.Clientset, target scalingv1.AutoscalingDefinitionScaleTarget) ([]corev1.Pod, error) {
+	set := labels.Set{target.LabelName: target.MatchLabel}
+	var selector *v1.LabelSelector
+	if target.TargetType == "deployment" {
+		deployments, err := client.ExtensionsV1beta1().Deployments(target.MatchNamespace).List(v1.ListOptions{LabelSelector: set.AsSelector().String()})
+		if err != nil {
+			return nil, err
+		}
+		if deployments.Items == nil || len(deployments.Items) <= 0 {
+			return nil, errors.New("target deployment not found")
+		}
+		selector = deployments.Items[0].Spec.Selector
+	} else if target.TargetType == "replicaset" {
+		replicasets, err := client.ExtensionsV1beta1().ReplicaSets(target.MatchNamespace).List(v1.ListOptions{LabelSelector: set.AsSelector().String()})
+		if err != nil {
+			return nil, err
+		}
+		if replicasets.Items == nil || len(replicasets.Items) <= 0 {
+			return nil, errors.New("target replicaset not found")
+		}
+		selector = replicasets.Items[0].Spec.Selector
+	} else {
+		return nil, errors.New("not recognized target type")
+	}
+	podSelector, err := v1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.CoreV1().Pods(target.MatchNamespace).List(v1.ListOptions{LabelSelector: podSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
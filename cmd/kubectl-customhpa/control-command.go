@@ -0,0 +1,42 @@
+package main
+
+import (
+	"custom-hpa/autoscaler"
+	"custom-hpa/clients"
+	"fmt"
+)
+
+func runPause(args []string) error {
+	flags, clientFlags := newFlagSet("pause")
+	name, err := requireName("pause", parseArgs(flags, args))
+	if err != nil {
+		return err
+	}
+	ctx, err := clientFlags.connect()
+	if err != nil {
+		return err
+	}
+	paused := "true"
+	if _, err := clients.SetDefinitionAnnotation(ctx.definitionClient, ctx.namespace, name, autoscaler.PausedAnnotation, &paused); err != nil {
+		return err
+	}
+	fmt.Printf("autoscalingdefinition/%s paused\n", name)
+	return nil
+}
+
+func runResume(args []string) error {
+	flags, clientFlags := newFlagSet("resume")
+	name, err := requireName("resume", parseArgs(flags, args))
+	if err != nil {
+		return err
+	}
+	ctx, err := clientFlags.connect()
+	if err != nil {
+		return err
+	}
+	if _, err := clients.SetDefinitionAnnotation(ctx.definitionClient, ctx.namespace, name, autoscaler.PausedAnnotation, nil); err != nil {
+		return err
+	}
+	fmt.Printf("autoscalingdefinition/%s resumed\n", name)
+	return nil
+}
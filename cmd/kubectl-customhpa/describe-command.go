@@ -0,0 +1,114 @@
+package main
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/autoscaler"
+	"custom-hpa/clients"
+	"fmt"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func runDescribe(args []string) error {
+	flags, clientFlags := newFlagSet("describe")
+	name, err := requireName("describe", parseArgs(flags, args))
+	if err != nil {
+		return err
+	}
+	ctx, err := clientFlags.connect()
+	if err != nil {
+		return err
+	}
+	definition, err := ctx.definitionClient.ScalingV1().AutoscalingDefinitions(ctx.namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	autoscaler.FillDefinitionDefaultValues(definition)
+	now := time.Now()
+	control := autoscaler.ReadScalingControl(definition.Annotations, now)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", definition.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", definition.Namespace)
+	fmt.Fprintf(w, "Target:\t%s in namespace %s\n", formatTarget(definition.Spec.ScaleTarget), definition.Spec.ScaleTarget.MatchNamespace)
+	fmt.Fprintf(w, "Mode:\t%s\n", formatEmpty(definition.Spec.Mode, "auto"))
+	fmt.Fprintf(w, "Replicas:\t%s current, min %d, max %d, scaling step %d\n", currentReplicas(ctx, *definition),
+		definition.Spec.MinReplicas, definition.Spec.MaxReplicas, definition.Spec.ScalingStep)
+	fmt.Fprintf(w, "Interval between autoscaling:\t%s\n", definition.Spec.IntervalBetweenAutoscaling)
+	fmt.Fprintf(w, "State:\t%s (%s)\n", control.State(), control.Message())
+	fmt.Fprintf(w, "Cooldown:\t%s\n", formatCooldown(definition.Status.CooldownUntil, now))
+	fmt.Fprintf(w, "Last scale:\t%s\n", formatAge(definition.Status.LastScaleTime, now))
+	if definition.Status.RecommendedReplicas > 0 {
+		fmt.Fprintf(w, "Recommended replicas:\t%d (%s)\n", definition.Status.RecommendedReplicas, formatAge(definition.Status.LastRecommendationTime, now))
+	}
+
+	fmt.Fprintln(w, "Metrics:")
+	fmt.Fprintln(w, "  NAME\tALGORITHM\tVALUE\tPREDICTED\tSCALE DOWN\tSCALE UP\tUPDATED")
+	for _, metric := range definition.Spec.Metrics {
+		metricStatus := findMetricStatus(definition.Status, metric.Name)
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\n", metric.Name, metric.Algorithm,
+			formatEmpty(metricStatus.Value, "<unknown>"), formatEmpty(metricStatus.PredictedValue, "-"),
+			formatEmpty(metric.ScaleDownValue, "-"), formatEmpty(metric.ScaleUpValue, "-"), formatAge(metricStatus.LastUpdateTime, now))
+	}
+
+	if len(definition.Status.Conditions) > 0 {
+		fmt.Fprintln(w, "Conditions:")
+		fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+		for _, condition := range definition.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason,
+				formatAge(condition.LastTransitionTime, now), condition.Message)
+		}
+	}
+
+	fmt.Fprintln(w, "Decision history:")
+	if len(definition.Status.Decisions) <= 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  TIME\tMETRIC\tREPLICAS\tPOLICIES\tREASON")
+		for i := len(definition.Status.Decisions) - 1; i >= 0; i-- {
+			decision := definition.Status.Decisions[i]
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", decision.Time.Format(time.RFC3339), formatEmpty(decision.Metric, "-"),
+				formatReplicaChange(decision), formatEmpty(strings.Join(decision.Policies, ","), "-"), decision.Reason)
+		}
+	}
+
+	fmt.Fprintln(w, "Events:")
+	events, err := clients.ListDefinitionEvents(ctx.client, *definition)
+	if err != nil {
+		fmt.Fprintf(w, "  cannot list events: %s\n", err.Error())
+	} else if len(events) <= 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  TIME\tTYPE\tREASON\tMESSAGE")
+		for _, event := range events {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", event.LastTimestamp.Format(time.RFC3339), event.Type, event.Reason, event.Message)
+		}
+	}
+	return w.Flush()
+}
+
+func findMetricStatus(status scalingv1.AutoscalingDefinitionStatus, name string) scalingv1.AutoscalingDefinitionMetricStatus {
+	for _, metricStatus := range status.Metrics {
+		if metricStatus.Name == name {
+			return metricStatus
+		}
+	}
+	return scalingv1.AutoscalingDefinitionMetricStatus{Name: name}
+}
+
+func formatReplicaChange(decision scalingv1.AutoscalingDefinitionDecision) string {
+	if decision.FromReplicas == decision.ToReplicas && decision.FromReplicas == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d -> %d", decision.FromReplicas, decision.ToReplicas)
+}
+
+func formatEmpty(value string, fallback string) string {
+	if len(value) <= 0 {
+		return fallback
+	}
+	return value
+}
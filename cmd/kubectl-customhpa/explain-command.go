@@ -0,0 +1,121 @@
+package main
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/autoscaler"
+	"fmt"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"time"
+)
+
+func runExplain(args []string) error {
+	flags, clientFlags := newFlagSet("explain")
+	name, err := requireName("explain", parseArgs(flags, args))
+	if err != nil {
+		return err
+	}
+	ctx, err := clientFlags.connect()
+	if err != nil {
+		return err
+	}
+	definition, err := ctx.definitionClient.ScalingV1().AutoscalingDefinitions(ctx.namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	autoscaler.FillDefinitionDefaultValues(definition)
+	now := time.Now()
+
+	decision := definition.Status.LastDecision
+	if decision.Time.IsZero() {
+		fmt.Printf("No scaling decision of %s was recorded yet.\n", definition.Name)
+	} else {
+		action := "Scaled"
+		if definition.Status.LastRecommendationTime.Equal(&decision.Time) {
+			action = "Recommended scaling"
+		}
+		fmt.Printf("%s %s from %d to %d replicas %s (%s).\n", action, formatTarget(definition.Spec.ScaleTarget),
+			decision.FromReplicas, decision.ToReplicas, formatAge(decision.Time, now), decision.Time.Format(time.RFC3339))
+		fmt.Printf("Reason: %s\n", decision.Reason)
+		if len(decision.Policies) > 0 {
+			fmt.Printf("Policies: %s\n", strings.Join(decision.Policies, ", "))
+		}
+		if metric, ok := findMetric(definition.Spec, decision.Metric); ok {
+			fmt.Println(explainMetric(metric, findMetricStatus(definition.Status, metric.Name)))
+		}
+		for _, input := range decision.Inputs {
+			fmt.Println(explainDecisionInput(input))
+		}
+	}
+
+	if skipped := countSkippedDecisions(definition.Status.Decisions, decision); skipped > 0 {
+		fmt.Printf("%d later evaluations requested scaling without changing replicas, see kubectl customhpa describe %s.\n", skipped, definition.Name)
+	}
+
+	control := autoscaler.ReadScalingControl(definition.Annotations, now)
+	fmt.Printf("Current state: %s\n", control.Message())
+	if cooldown := formatCooldown(definition.Status.CooldownUntil, now); cooldown != "-" {
+		fmt.Printf("Next scaling by metrics is blocked by intervalBetweenAutoscaling for %s.\n", cooldown)
+	}
+	for _, condition := range definition.Status.Conditions {
+		if condition.Status == "False" && len(condition.Message) > 0 {
+			fmt.Printf("Condition %s: %s\n", condition.Type, condition.Message)
+		}
+	}
+	return nil
+}
+
+func findMetric(spec scalingv1.AutoscalingDefinitionSpec, name string) (scalingv1.AutoscalingDefinitionMetric, bool) {
+	for _, metric := range spec.Metrics {
+		if metric.Name == name && len(name) > 0 {
+			return metric, true
+		}
+	}
+	return scalingv1.AutoscalingDefinitionMetric{}, false
+}
+
+func explainMetric(metric scalingv1.AutoscalingDefinitionMetric, metricStatus scalingv1.AutoscalingDefinitionMetricStatus) string {
+	explanation := fmt.Sprintf("Metric %s (algorithm %s, value scope %s): last value %s", metric.Name, metric.Algorithm,
+		formatEmpty(metric.ValueScope, "perReplica"), formatEmpty(metricStatus.Value, "<unknown>"))
+	if len(metricStatus.PredictedValue) > 0 {
+		explanation += ", predicted value " + metricStatus.PredictedValue
+	}
+	if len(metric.ScaleDownValue) > 0 || len(metric.ScaleUpValue) > 0 {
+		explanation += fmt.Sprintf(", scale down value %s, scale up value %s, majority of last %d tests must pass, test passes when %d%% of scraped values fulfill bound",
+			formatEmpty(metric.ScaleDownValue, "-"), formatEmpty(metric.ScaleUpValue, "-"),
+			metric.NumOfTests, metric.PercentageOfTestConditionFulfillment)
+	}
+	if len(metric.TargetValue) > 0 {
+		explanation += ", target value " + metric.TargetValue
+	}
+	if len(metric.ExternalEndpoint) > 0 {
+		explanation += fmt.Sprintf(", evaluated by external algorithm at %s over %s, reactive evaluation is used when it fails",
+			metric.ExternalEndpoint, formatEmpty(metric.ExternalProtocol, "http"))
+	}
+	return explanation
+}
+
+func explainDecisionInput(input scalingv1.AutoscalingDefinitionDecisionInput) string {
+	explanation := fmt.Sprintf("Input %s: value %s from %d scraped values", input.Metric, formatEmpty(input.Value, "<unknown>"), input.ScrapeCount)
+	if input.NumOfTests > 0 {
+		explanation += fmt.Sprintf(", %d of %d lower bound tests and %d of %d upper bound tests passed",
+			input.LowerBoundTestsPassed, input.NumOfTests, input.UpperBoundTestsPassed, input.NumOfTests)
+	}
+	if len(input.PredictedValue) > 0 {
+		explanation += ", predicted value " + input.PredictedValue
+	}
+	for _, exogenousRegressor := range input.ExogenousRegressors {
+		explanation += fmt.Sprintf(", exogenous regressor %s %s", exogenousRegressor.Name, exogenousRegressor.Value)
+	}
+	return explanation
+}
+
+func countSkippedDecisions(decisions []scalingv1.AutoscalingDefinitionDecision, lastDecision scalingv1.AutoscalingDefinitionDecision) int {
+	skipped := 0
+	for _, decision := range decisions {
+		if decision.Time.After(lastDecision.Time.Time) && decision.FromReplicas == decision.ToReplicas {
+			skipped++
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"custom-hpa/pkg/client/clientset/versioned"
+	"errors"
+	"flag"
+	"fmt"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage       string
+	description string
+	run         func(args []string) error
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"status":   {"status [NAME]", "Show replicas, last metric values, predictions and cooldown of autoscaling definitions", runStatus},
+		"describe": {"describe NAME", "Show definition, metrics, conditions and decision history", runDescribe},
+		"pause":    {"pause NAME", "Pause autoscaling of definition", runPause},
+		"resume":   {"resume NAME", "Resume paused autoscaling of definition", runResume},
+		"validate": {"validate -f FILE", "Validate autoscaling definitions offline, same validation is used by admission webhook", runValidate},
+		"explain":  {"explain NAME", "Explain why the last scaling decision was made", runExplain},
+		"simulate": {"simulate -f FILE --series FILE", "Replay recorded series through autoscaling pipeline offline and report replicas, SLO violations and cost", runSimulate},
+	}
+}
+
+type clientFlags struct {
+	kubeconfig *string
+	context    *string
+	namespace  *string
+}
+
+type commandContext struct {
+	namespace        string
+	client           *kubernetes.Clientset
+	definitionClient versioned.Interface
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		if os.Args[1] != "help" && os.Args[1] != "-h" && os.Args[1] != "--help" {
+			fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
+		}
+		printUsage()
+		os.Exit(1)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Inspect and operate autoscaling definitions of custom-hpa.")
+	fmt.Fprintln(os.Stderr, "\nUsage:\n  kubectl customhpa COMMAND [flags]\n\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-18s %s\n", commands[name].usage, commands[name].description)
+	}
+}
+
+func newFlagSet(name string) (*flag.FlagSet, clientFlags) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n  kubectl customhpa %s [flags]\n\nFlags:\n", commands[name].usage)
+		flags.PrintDefaults()
+	}
+	namespace := new(string)
+	flags.StringVar(namespace, "namespace", "", "Namespace of autoscaling definitions, namespace of current context is used when not set")
+	flags.StringVar(namespace, "n", "", "Shorthand for --namespace")
+	return flags, clientFlags{
+		kubeconfig: flags.String("kubeconfig", "", "Path to the kubeconfig file"),
+		context:    flags.String("context", "", "Name of the kubeconfig context to use"),
+		namespace:  namespace,
+	}
+}
+
+// parseArgs parses flags placed anywhere between positional arguments, as kubectl does
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) <= 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func requireName(name string, positional []string) (string, error) {
+	if len(positional) != 1 {
+		return "", errors.New(fmt.Sprintf("%s requires exactly one definition name", name))
+	}
+	return positional[0], nil
+}
+
+func (f clientFlags) connect() (*commandContext, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if len(*f.kubeconfig) > 0 {
+		loadingRules.ExplicitPath = *f.kubeconfig
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{CurrentContext: *f.context})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	namespace := *f.namespace
+	if len(namespace) <= 0 {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, err
+		}
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	definitionClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &commandContext{namespace: namespace, client: client, definitionClient: definitionClient}, nil
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/autoscaler"
+	"custom-hpa/validation"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	model2 "github.com/prometheus/common/model"
+	"io"
+	"io/ioutil"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func runSimulate(args []string) error {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n  kubectl customhpa %s [flags]\n\n", commands["simulate"].usage)
+		fmt.Fprintln(os.Stderr, "Series is CSV with timestamp and value columns, JSON array of {\"time\", \"value\"} objects")
+		fmt.Fprintln(os.Stderr, "or Prometheus query_range response with single series. Timestamps are RFC3339 or unix seconds.\n\nFlags:")
+		flags.PrintDefaults()
+	}
+	filename := flags.String("f", "", "File with autoscaling definition")
+	name := flags.String("name", "", "Name of simulated definition, when file contains more definitions")
+	seriesFilename := flags.String("series", "", "File with recorded series of metric values, or total load when --capacity is set")
+	exogenousFilenames := flags.String("exogenous", "", "Comma separated name=file pairs with recorded series of exogenous regressors, required by ARIMAX metrics,\n"+
+		"name is omitted for metric with single regressor")
+	metricName := flags.String("metric", "", "Name of simulated metric, the first metric of definition is used when not set")
+	replicas := flags.Int("replicas", 0, "Initial replicas of simulated target, minReplicas is used when not set")
+	startupDelay := flags.Duration("startup-delay", 0, "Time after which started pod becomes ready")
+	capacity := flags.Float64("capacity", 0, "Load handled by one ready pod, series is split among ready pods when set")
+	slo := flags.Float64("slo", 0, "Value per ready pod above which scrape interval violates SLO, --capacity is used when not set")
+	output := flags.String("o", "table", "Output format: table, csv or json")
+	verbose := flags.Bool("v", false, "Print log of autoscaling pipeline")
+	parseArgs(flags, args)
+	if len(*filename) <= 0 || len(*seriesFilename) <= 0 {
+		return errors.New("simulate requires definition set by -f and series set by --series")
+	}
+	if !*verbose {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	definition, err := readSimulatedDefinition(*filename, *name)
+	if err != nil {
+		return err
+	}
+	if allErrs := validation.ValidateAutoscalingDefinition(definition); len(allErrs) > 0 {
+		return errors.New(fmt.Sprintf("autoscalingdefinition/%s is invalid: %s", definition.Name, allErrs.ToAggregate().Error()))
+	}
+	series, err := readSeries(*seriesFilename)
+	if err != nil {
+		return err
+	}
+	options := autoscaler.SimulationOptions{
+		MetricName:      *metricName,
+		InitialReplicas: *replicas,
+		PodStartupDelay: *startupDelay,
+		PodCapacity:     *capacity,
+		SloValue:        *slo,
+	}
+	if options.ExogenousSeries, err = readExogenousSeries(*exogenousFilenames); err != nil {
+		return err
+	}
+	result, err := autoscaler.Simulate(*definition, series, options)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(*output) {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "csv":
+		return printSimulationCsv(result)
+	case "table":
+		return printSimulationTable(result)
+	}
+	return errors.New(fmt.Sprintf("unsupported output format %s", *output))
+}
+
+func readSimulatedDefinition(filename string, name string) (*scalingv1.AutoscalingDefinition, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	documents := utilyaml.NewYAMLReader(bufio.NewReader(file))
+	for {
+		document, err := documents.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(strings.TrimSpace(string(document))) <= 0 {
+			continue
+		}
+		definition, err := decodeDefinition(document)
+		if err != nil {
+			return nil, err
+		}
+		if definition != nil && (len(name) <= 0 || definition.Name == name) {
+			return definition, nil
+		}
+	}
+	if len(name) > 0 {
+		return nil, errors.New(fmt.Sprintf("autoscalingdefinition/%s not found in %s", name, filename))
+	}
+	return nil, errors.New(fmt.Sprintf("no autoscaling definitions found in %s", filename))
+}
+
+// readExogenousSeries reads series of name=file pairs, series of file without name is stored under empty name
+func readExogenousSeries(filenames string) (map[string][]autoscaler.SimulationSample, error) {
+	exogenousSeries := map[string][]autoscaler.SimulationSample{}
+	for _, pair := range strings.Split(filenames, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) <= 0 {
+			continue
+		}
+		name, filename := "", pair
+		if i := strings.Index(pair, "="); i >= 0 {
+			name, filename = pair[:i], pair[i+1:]
+		}
+		if _, ok := exogenousSeries[name]; ok {
+			return nil, errors.New(fmt.Sprintf("series of exogenous regressor %q is set more times", name))
+		}
+		series, err := readSeries(filename)
+		if err != nil {
+			return nil, err
+		}
+		exogenousSeries[name] = series
+	}
+	return exogenousSeries, nil
+}
+
+// readSeries detects format of series by extension and first character of file
+func readSeries(filename string) ([]autoscaler.SimulationSample, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	var series []autoscaler.SimulationSample
+	switch {
+	case strings.ToLower(filepath.Ext(filename)) == ".csv" || (len(data) > 0 && data[0] != '[' && data[0] != '{'):
+		series, err = parseCsvSeries(data)
+	case len(data) > 0 && data[0] == '[':
+		err = json.Unmarshal(data, &series)
+	default:
+		series, err = parsePrometheusSeries(data)
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("cannot read series %s: %s", filename, err.Error()))
+	}
+	if len(series) <= 0 {
+		return nil, errors.New(fmt.Sprintf("series %s has no samples", filename))
+	}
+	return series, nil
+}
+
+func parseCsvSeries(data []byte) ([]autoscaler.SimulationSample, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	var series []autoscaler.SimulationSample
+	for i, record := range records {
+		if len(record) < 2 {
+			return nil, errors.New(fmt.Sprintf("line %d should have timestamp and value columns", i+1))
+		}
+		value, err := strconv.ParseFloat(record[1], 64)
+		if err != nil && i == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", i+1, err.Error()))
+		}
+		timestamp, err := parseTimestamp(record[0])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", i+1, err.Error()))
+		}
+		series = append(series, autoscaler.SimulationSample{Time: timestamp, Value: value})
+	}
+	return series, nil
+}
+
+func parsePrometheusSeries(data []byte) ([]autoscaler.SimulationSample, error) {
+	var export struct {
+		Data struct {
+			ResultType string        `json:"resultType"`
+			Result     model2.Matrix `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+	if export.Data.ResultType != "matrix" {
+		return nil, errors.New(fmt.Sprintf("result type %q of Prometheus export is not matrix, export result of range query", export.Data.ResultType))
+	}
+	if len(export.Data.Result) != 1 {
+		return nil, errors.New(fmt.Sprintf("Prometheus export contains %d series, aggregate query into single series", len(export.Data.Result)))
+	}
+	var series []autoscaler.SimulationSample
+	for _, sample := range export.Data.Result[0].Values {
+		series = append(series, autoscaler.SimulationSample{Time: sample.Timestamp.Time(), Value: float64(sample.Value)})
+	}
+	return series, nil
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*1e9)).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func printSimulationTable(result autoscaler.SimulationResult) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tLOAD\tVALUE\tPREDICTED\tREPLICAS\tREADY\tDECISION")
+	for _, step := range result.Timeline {
+		decision := "-"
+		if step.Scaled {
+			decision = step.Reason
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n", step.Time.Format(time.RFC3339), formatFloat(step.Load), formatFloat(step.MetricValue),
+			formatPredictedValue(step.PredictedValue), step.Replicas, step.ReadyReplicas, decision)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Print(formatSimulationSummary(result))
+	return nil
+}
+
+func printSimulationCsv(result autoscaler.SimulationResult) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"time", "load", "value", "predicted", "replicas", "ready", "scaled", "reason"})
+	for _, step := range result.Timeline {
+		predictedValue := ""
+		if step.PredictedValue != nil {
+			predictedValue = formatFloat(*step.PredictedValue)
+		}
+		w.Write([]string{step.Time.Format(time.RFC3339), formatFloat(step.Load), formatFloat(step.MetricValue), predictedValue,
+			strconv.Itoa(step.Replicas), strconv.Itoa(step.ReadyReplicas), strconv.FormatBool(step.Scaled), step.Reason})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatSimulationSummary(result autoscaler.SimulationResult) string {
+	minReplicas, maxReplicas := 0, 0
+	for i, step := range result.Timeline {
+		if i == 0 || step.Replicas < minReplicas {
+			minReplicas = step.Replicas
+		}
+		if step.Replicas > maxReplicas {
+			maxReplicas = step.Replicas
+		}
+	}
+	var duration time.Duration
+	if len(result.Timeline) > 0 {
+		duration = result.Timeline[len(result.Timeline)-1].Time.Sub(result.Timeline[0].Time)
+	}
+	summary := fmt.Sprintf("Simulated metric %s over %s: %d scaling decisions, replicas between %d and %d.\n",
+		result.Metric, duration.String(), len(result.Decisions), minReplicas, maxReplicas)
+	summary += fmt.Sprintf("Cost: %s replica-minutes.\n", formatFloat(result.ReplicaMinutes))
+	summary += fmt.Sprintf("SLO violations: %d scrape intervals (%s minutes).\n", result.SloViolations, formatFloat(result.SloViolationMinutes))
+	return summary
+}
+
+func formatPredictedValue(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return formatFloat(*value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/autoscaler"
+	"custom-hpa/clients"
+	"fmt"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func runStatus(args []string) error {
+	flags, clientFlags := newFlagSet("status")
+	allNamespaces := flags.Bool("all-namespaces", false, "Show definitions of all namespaces")
+	positional := parseArgs(flags, args)
+	ctx, err := clientFlags.connect()
+	if err != nil {
+		return err
+	}
+	namespace := ctx.namespace
+	if *allNamespaces {
+		namespace = meta_v1.NamespaceAll
+	}
+	var definitions []scalingv1.AutoscalingDefinition
+	if len(positional) > 0 {
+		for _, name := range positional {
+			definition, err := ctx.definitionClient.ScalingV1().AutoscalingDefinitions(namespace).Get(name, meta_v1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			definitions = append(definitions, *definition)
+		}
+	} else {
+		list, err := ctx.definitionClient.ScalingV1().AutoscalingDefinitions(namespace).List(meta_v1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		definitions = list.Items
+	}
+	if len(definitions) <= 0 {
+		fmt.Fprintln(os.Stderr, "No autoscaling definitions found.")
+		return nil
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 3, ' ', 0)
+	header := "NAME\tTARGET\tSTATE\tREPLICAS\tMIN\tMAX\tMETRICS\tPREDICTIONS\tCOOLDOWN\tLAST SCALE"
+	if *allNamespaces {
+		header = "NAMESPACE\t" + header
+	}
+	fmt.Fprintln(w, header)
+	for _, definition := range definitions {
+		autoscaler.FillDefinitionDefaultValues(&definition)
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s",
+			definition.Name,
+			formatTarget(definition.Spec.ScaleTarget),
+			autoscaler.ReadScalingControl(definition.Annotations, now).State(),
+			currentReplicas(ctx, definition),
+			definition.Spec.MinReplicas,
+			definition.Spec.MaxReplicas,
+			formatMetricValues(definition.Status.Metrics, false),
+			formatMetricValues(definition.Status.Metrics, true),
+			formatCooldown(definition.Status.CooldownUntil, now),
+			formatAge(definition.Status.LastScaleTime, now))
+		if *allNamespaces {
+			row = definition.Namespace + "\t" + row
+		}
+		fmt.Fprintln(w, row)
+	}
+	return w.Flush()
+}
+
+func currentReplicas(ctx *commandContext, definition scalingv1.AutoscalingDefinition) string {
+	scale, err := clients.GetScale(ctx.client, definition.Spec.ScaleTarget)
+	if err != nil {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%d/%d", scale.Status.Replicas, scale.Spec.Replicas)
+}
+
+func formatTarget(target scalingv1.AutoscalingDefinitionScaleTarget) string {
+	return fmt.Sprintf("%s/%s=%s", strings.ToLower(target.TargetType), target.LabelName, target.MatchLabel)
+}
+
+func formatMetricValues(metricStatuses []scalingv1.AutoscalingDefinitionMetricStatus, predicted bool) string {
+	var values []string
+	for _, metricStatus := range metricStatuses {
+		value := metricStatus.Value
+		if predicted {
+			value = metricStatus.PredictedValue
+		}
+		if len(value) > 0 {
+			values = append(values, metricStatus.Name+"="+value)
+		}
+	}
+	if len(values) <= 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ",")
+}
+
+func formatCooldown(cooldownUntil meta_v1.Time, now time.Time) string {
+	if cooldownUntil.IsZero() || !now.Before(cooldownUntil.Time) {
+		return "-"
+	}
+	return cooldownUntil.Time.Sub(now).Round(time.Second).String()
+}
+
+func formatAge(t meta_v1.Time, now time.Time) string {
+	if t.IsZero() {
+		return "<never>"
+	}
+	return now.Sub(t.Time).Round(time.Second).String() + " ago"
+}
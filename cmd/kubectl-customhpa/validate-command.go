@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	scalingv2 "custom-hpa/apis/scaling/v2"
+	"custom-hpa/validation"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"os"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+func runValidate(args []string) error {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n  kubectl customhpa %s [flags]\n\nFlags:\n", commands["validate"].usage)
+		flags.PrintDefaults()
+	}
+	filename := flags.String("f", "", "File with autoscaling definitions, use - to read from standard input")
+	parseArgs(flags, args)
+	if len(*filename) <= 0 {
+		return errors.New("validate requires file set by -f")
+	}
+	var reader io.Reader = os.Stdin
+	if *filename != "-" {
+		file, err := os.Open(*filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	documents := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+	validated, invalid := 0, 0
+	for {
+		document, err := documents.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(strings.TrimSpace(string(document))) <= 0 {
+			continue
+		}
+		definition, err := decodeDefinition(document)
+		if err != nil {
+			return err
+		}
+		if definition == nil {
+			continue
+		}
+		validated++
+		allErrs := validation.ValidateAutoscalingDefinition(definition)
+		if len(allErrs) <= 0 {
+			fmt.Printf("autoscalingdefinition/%s is valid\n", definition.Name)
+			continue
+		}
+		invalid++
+		fmt.Printf("autoscalingdefinition/%s is invalid:\n", definition.Name)
+		for _, fieldErr := range allErrs {
+			fmt.Printf("  - %s\n", fieldErr.Error())
+		}
+	}
+	if validated <= 0 {
+		return errors.New(fmt.Sprintf("no autoscaling definitions found in %s", *filename))
+	}
+	if invalid > 0 {
+		return errors.New(fmt.Sprintf("%d of %d autoscaling definitions are invalid", invalid, validated))
+	}
+	return nil
+}
+
+// decodeDefinition decodes scaling.com/v1 or scaling.com/v2 definition, v2 definitions are converted to v1 as by conversion webhook.
+// Documents of other kinds are skipped.
+func decodeDefinition(document []byte) (*scalingv1.AutoscalingDefinition, error) {
+	data, err := yaml.YAMLToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	typeMeta := meta_v1.TypeMeta{}
+	if err := json.Unmarshal(data, &typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.Kind != "AutoscalingDefinition" {
+		return nil, nil
+	}
+	switch typeMeta.APIVersion {
+	case scalingv1.SchemeGroupVersion.String():
+		definition := &scalingv1.AutoscalingDefinition{}
+		if err := json.Unmarshal(data, definition); err != nil {
+			return nil, err
+		}
+		return definition, nil
+	case scalingv2.SchemeGroupVersion.String():
+		definition := &scalingv2.AutoscalingDefinition{}
+		if err := json.Unmarshal(data, definition); err != nil {
+			return nil, err
+		}
+		return scalingv2.ConvertToV1(definition), nil
+	}
+	return nil, errors.New(fmt.Sprintf("unsupported apiVersion %s of AutoscalingDefinition", typeMeta.APIVersion))
+}
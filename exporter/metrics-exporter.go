@@ -0,0 +1,94 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type gauge struct {
+	help   string
+	series map[string]float64
+}
+
+var (
+	mutex  sync.Mutex
+	gauges = make(map[string]*gauge)
+)
+
+// SetGauge stores value of gauge series identified by name and labels
+func SetGauge(name string, help string, labels map[string]string, value float64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	g, ok := gauges[name]
+	if !ok {
+		g = &gauge{help: help, series: make(map[string]float64)}
+		gauges[name] = g
+	}
+	g.series[formatLabels(labels)] = value
+}
+
+// DeleteGauge removes gauge series identified by name and labels
+func DeleteGauge(name string, labels map[string]string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if g, ok := gauges[name]; ok {
+		delete(g.series, formatLabels(labels))
+	}
+}
+
+// Handler writes all gauges in prometheus text exposition format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		var names []string
+		for name := range gauges {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			g := gauges[name]
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, g.help, name)
+			var series []string
+			for labels := range g.series {
+				series = append(series, labels)
+			}
+			sort.Strings(series)
+			for _, labels := range series {
+				fmt.Fprintf(w, "%s%s %g\n", name, labels, g.series[labels])
+			}
+		}
+	})
+}
+
+func ListenAndServe(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	go func() {
+		if err := http.ListenAndServe(address, mux); err != nil {
+			log.Printf("Metrics exporter error: %s", err.Error())
+		}
+	}()
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) <= 0 {
+		return ""
+	}
+	var keys []string
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(labels[key])
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", key, value))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
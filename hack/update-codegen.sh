@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
+CODEGEN_PKG=${CODEGEN_PKG:-$(cd "${SCRIPT_ROOT}"; ls -d -1 ./vendor/k8s.io/code-generator 2>/dev/null || echo ../code-generator)}
+
+bash "${CODEGEN_PKG}"/generate-groups.sh all \
+  custom-hpa/pkg/client custom-hpa/apis \
+  scaling:v1 \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
+
+bash "${CODEGEN_PKG}"/generate-groups.sh deepcopy \
+  custom-hpa/pkg/client custom-hpa/apis \
+  scaling:v2 \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
@@ -2,9 +2,11 @@ package main
 
 import (
 	"custom-hpa/autoscaler"
-	"custom-hpa/clients"
+	"custom-hpa/exporter"
+	"custom-hpa/pkg/client/clientset/versioned"
+	"custom-hpa/webhook"
+	"flag"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"os"
@@ -12,6 +14,12 @@ import (
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "Run whole autoscaling pipeline, but only record recommended replicas instead of scaling targets")
+	metricsAddress := flag.String("metrics-address", ":8080", "Address of prometheus metrics endpoint")
+	webhookAddress := flag.String("webhook-address", ":8443", "Address of admission webhook server")
+	tlsCertFile := flag.String("tls-cert-file", "", "TLS certificate of admission webhook server. Webhook server is disabled when not set")
+	tlsKeyFile := flag.String("tls-private-key-file", "", "TLS private key of admission webhook server")
+	flag.Parse()
 
 	config, err := rest.InClusterConfig()
 	if err != nil {
@@ -29,12 +37,13 @@ func main() {
 		panic(err.Error())
 	}
 
-	Scheme := scheme.Scheme
-	_ = clients.AddToScheme(Scheme)
-
-	client, err := clients.NewForConfig(config, Scheme)
+	client, err := versioned.NewForConfig(config)
 	if err != nil {
 		panic(err.Error())
 	}
-	autoscaler.MainAutoscalingLoop(client, clientset)
+	exporter.ListenAndServe(*metricsAddress)
+	if len(*tlsCertFile) > 0 {
+		webhook.ListenAndServeTLS(*webhookAddress, *tlsCertFile, *tlsKeyFile)
+	}
+	autoscaler.MainAutoscalingLoop(client, clientset, autoscaler.AutoscalerOptions{DryRun: *dryRun})
 }
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ExogenousRegressorName names regressor set by exogenousRegressorQuery, exogenousRegressorCoefficient and exogenousRegressorMaxValue fields
+	ExogenousRegressorName = "exogenousRegressor"
+
+	ExogenousAggregationTrimmedMean = "trimmedmean"
+	ExogenousAggregationMean        = "mean"
+	ExogenousAggregationMedian      = "median"
+	ExogenousAggregationMax         = "max"
+	ExogenousAggregationMin         = "min"
+	ExogenousAggregationLast        = "last"
+)
+
+var supportedExogenousAggregations = []string{ExogenousAggregationTrimmedMean, ExogenousAggregationMean, ExogenousAggregationMedian,
+	ExogenousAggregationMax, ExogenousAggregationMin, ExogenousAggregationLast}
+
+// ExogenousRegressors returns exogenous regressors of metric with empty fields filled. Regressor of exogenousRegressorQuery field
+// is returned, when exogenousRegressors list is empty.
+func ExogenousRegressors(metric scalingv1.AutoscalingDefinitionMetric) []scalingv1.AutoscalingDefinitionExogenousRegressor {
+	regressors := metric.ExogenousRegressors
+	if len(regressors) <= 0 && len(metric.ExogenousRegressorQuery) > 0 {
+		regressors = []scalingv1.AutoscalingDefinitionExogenousRegressor{{
+			Name:        ExogenousRegressorName,
+			Query:       metric.ExogenousRegressorQuery,
+			Coefficient: metric.ExogenousRegressorCoefficient,
+			MaxValue:    metric.ExogenousRegressorMaxValue,
+		}}
+	}
+	var result []scalingv1.AutoscalingDefinitionExogenousRegressor
+	for _, regressor := range regressors {
+		if len(regressor.Source) <= 0 {
+			regressor.Source = metric.MetricType
+		}
+		if len(regressor.PrometheusPath) <= 0 {
+			regressor.PrometheusPath = metric.PrometheusPath
+		}
+		if _, err := strconv.ParseFloat(regressor.Coefficient, 64); err != nil {
+			regressor.Coefficient = "0.0"
+		}
+		if regressor.Lag < 0 {
+			regressor.Lag = 0
+		}
+		if len(regressor.Aggregation) <= 0 {
+			regressor.Aggregation = ExogenousAggregationTrimmedMean
+		}
+		regressor.Aggregation = strings.ToLower(regressor.Aggregation)
+		result = append(result, regressor)
+	}
+	return result
+}
+
+// validateExogenousRegressors checks exogenousRegressors list, max value is required by every regressor, as it replaces value
+// of test interval with too few valid scrapes
+func validateExogenousRegressors(metric scalingv1.AutoscalingDefinitionMetric, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(metric.ExogenousRegressors) <= 0 {
+		return allErrs
+	}
+	if len(metric.ExogenousRegressorQuery) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("exogenousRegressorQuery"), metric.ExogenousRegressorQuery, "must not be set together with exogenousRegressors"))
+	}
+	names := map[string]bool{}
+	for i, regressor := range metric.ExogenousRegressors {
+		regressorPath := fldPath.Child("exogenousRegressors").Index(i)
+		if len(regressor.Name) <= 0 {
+			allErrs = append(allErrs, field.Required(regressorPath.Child("name"), ""))
+		} else if names[regressor.Name] {
+			allErrs = append(allErrs, field.Duplicate(regressorPath.Child("name"), regressor.Name))
+		}
+		names[regressor.Name] = true
+		if len(regressor.Query) <= 0 {
+			allErrs = append(allErrs, field.Required(regressorPath.Child("query"), ""))
+		}
+		if _, err := GetMetricSource(regressor.Source); len(regressor.Source) > 0 && err != nil {
+			allErrs = append(allErrs, field.NotSupported(regressorPath.Child("source"), regressor.Source, RegisteredMetricTypes()))
+		}
+		source := regressor.Source
+		if len(source) <= 0 {
+			source = metric.MetricType
+		}
+		if strings.ToLower(source) == MetricTypePrometheus && len(regressor.PrometheusPath) <= 0 && len(metric.PrometheusPath) <= 0 {
+			allErrs = append(allErrs, field.Required(regressorPath.Child("prometheusPath"), "required by prometheus source, when prometheusPath of metric is not set"))
+		}
+		allErrs = append(allErrs, validateFloat(regressor.Coefficient, regressorPath.Child("coefficient"))...)
+		if len(regressor.MaxValue) <= 0 {
+			allErrs = append(allErrs, field.Required(regressorPath.Child("maxValue"), ""))
+		}
+		allErrs = append(allErrs, validateFloat(regressor.MaxValue, regressorPath.Child("maxValue"))...)
+		if regressor.Lag < 0 {
+			allErrs = append(allErrs, field.Invalid(regressorPath.Child("lag"), regressor.Lag, "must be greater than or equal to 0"))
+		}
+		if len(regressor.Aggregation) > 0 && !ContainsFold(supportedExogenousAggregations, regressor.Aggregation) {
+			allErrs = append(allErrs, field.NotSupported(regressorPath.Child("aggregation"), regressor.Aggregation, supportedExogenousAggregations))
+		}
+	}
+	return allErrs
+}
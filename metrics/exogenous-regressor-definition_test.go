@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"reflect"
+	"testing"
+)
+
+func TestExogenousRegressors(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		MetricType:                 MetricTypePrometheus,
+		PrometheusPath:             "http://prometheus:9090",
+		ExogenousRegressorQuery:    "rate",
+		ExogenousRegressorMaxValue: "26",
+	}
+	expected := []scalingv1.AutoscalingDefinitionExogenousRegressor{
+		{Name: ExogenousRegressorName, Query: "rate", Source: MetricTypePrometheus, PrometheusPath: "http://prometheus:9090", Coefficient: "0.0", MaxValue: "26", Aggregation: ExogenousAggregationTrimmedMean},
+	}
+	if regressors := ExogenousRegressors(metric); !reflect.DeepEqual(regressors, expected) {
+		t.Errorf("ExogenousRegressors() = %+v, expected %+v", regressors, expected)
+	}
+
+	metric.ExogenousRegressorQuery = ""
+	metric.ExogenousRegressors = []scalingv1.AutoscalingDefinitionExogenousRegressor{
+		{Name: "misses", Query: "misses", Source: MetricTypeMemory, Coefficient: "0.5", MaxValue: "1", Lag: 2, Aggregation: "Last"},
+		{Name: "orders", Query: "orders", PrometheusPath: "http://shop-prometheus:9090", MaxValue: "50"},
+	}
+	expected = []scalingv1.AutoscalingDefinitionExogenousRegressor{
+		{Name: "misses", Query: "misses", Source: MetricTypeMemory, PrometheusPath: "http://prometheus:9090", Coefficient: "0.5", MaxValue: "1", Lag: 2, Aggregation: ExogenousAggregationLast},
+		{Name: "orders", Query: "orders", Source: MetricTypePrometheus, PrometheusPath: "http://shop-prometheus:9090", Coefficient: "0.0", MaxValue: "50", Aggregation: ExogenousAggregationTrimmedMean},
+	}
+	if regressors := ExogenousRegressors(metric); !reflect.DeepEqual(regressors, expected) {
+		t.Errorf("ExogenousRegressors() = %+v, expected %+v", regressors, expected)
+	}
+}
+
+func TestValidateExogenousRegressors(t *testing.T) {
+	fldPath := field.NewPath("metric")
+	tests := []struct {
+		name       string
+		query      string
+		regressors []scalingv1.AutoscalingDefinitionExogenousRegressor
+		expected   []string
+	}{
+		{
+			name: "valid regressors",
+			regressors: []scalingv1.AutoscalingDefinitionExogenousRegressor{{Name: "requests", Query: "rate", MaxValue: "100"}, {Name: "payload", Query: "size", MaxValue: "10", Lag: 1, Aggregation: "max"},
+				{Name: "orders", Query: "orders", Source: MetricTypePrometheus, PrometheusPath: "http://shop-prometheus:9090", MaxValue: "50"}},
+		},
+		{
+			name:       "prometheus regressor without address of regressor and metric",
+			regressors: []scalingv1.AutoscalingDefinitionExogenousRegressor{{Name: "orders", Query: "orders", Source: MetricTypePrometheus, MaxValue: "50"}},
+			expected:   []string{"metric.exogenousRegressors[0].prometheusPath"},
+		},
+		{
+			name:       "regressors together with exogenous regressor query",
+			query:      "rate",
+			regressors: []scalingv1.AutoscalingDefinitionExogenousRegressor{{Name: "requests", Query: "rate", MaxValue: "100"}},
+			expected:   []string{"metric.exogenousRegressorQuery"},
+		},
+		{
+			name: "invalid regressors",
+			regressors: []scalingv1.AutoscalingDefinitionExogenousRegressor{
+				{Name: "requests", Query: "rate", MaxValue: "100", Source: "graphite"},
+				{Name: "requests", MaxValue: "many", Lag: -1, Aggregation: "sum"},
+			},
+			expected: []string{"metric.exogenousRegressors[0].source", "metric.exogenousRegressors[1].name", "metric.exogenousRegressors[1].query",
+				"metric.exogenousRegressors[1].maxValue", "metric.exogenousRegressors[1].lag", "metric.exogenousRegressors[1].aggregation"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metric := scalingv1.AutoscalingDefinitionMetric{ExogenousRegressorQuery: test.query, ExogenousRegressors: test.regressors}
+			var fields []string
+			for _, err := range validateExogenousRegressors(metric, fldPath) {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, test.expected) {
+				t.Errorf("validateExogenousRegressors() errors of %v, expected %v", fields, test.expected)
+			}
+		})
+	}
+}
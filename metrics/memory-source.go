@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model2 "github.com/prometheus/common/model"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemorySource is in-memory metric source for tests and offline tools. Query returns the latest sample
+// of query not after spec time, address is ignored.
+type MemorySource struct {
+	mutex   sync.RWMutex
+	samples map[string][]model2.SamplePair
+}
+
+func NewMemorySource() *MemorySource {
+	return &MemorySource{samples: map[string][]model2.SamplePair{}}
+}
+
+func (s *MemorySource) AddSample(query string, at time.Time, value float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	samples := append(s.samples[query], model2.SamplePair{Timestamp: model2.TimeFromUnixNano(at.UnixNano()), Value: model2.SampleValue(value)})
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	s.samples[query] = samples
+}
+
+func (s *MemorySource) Query(ctx context.Context, spec MetricSourceSpec) (model2.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	samples, ok := s.samples[spec.Query]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("query %s has no samples", spec.Query))
+	}
+	at := model2.TimeFromUnixNano(spec.Time.UnixNano())
+	i := sort.Search(len(samples), func(i int) bool {
+		return samples[i].Timestamp.After(at)
+	})
+	if i <= 0 {
+		return model2.Vector{}, nil
+	}
+	return &model2.Scalar{Value: samples[i-1].Value, Timestamp: samples[i-1].Timestamp}, nil
+}
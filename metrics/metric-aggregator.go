@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AlgorithmDefault  = "default"
+	AlgorithmQueue    = "queue"
+	AlgorithmExternal = "external"
+)
+
+// Aggregator reduces scrapes of one test interval to value and results of lower and upper bound tests
+type Aggregator interface {
+	Aggregate(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64)
+}
+
+type AggregatorFunc func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64)
+
+func (f AggregatorFunc) Aggregate(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+	return f(scrapeList, metric, scaleDownValue, scaleUpValue)
+}
+
+// ValueAggregatorFunc aggregates scrapes to single value, bound tests of value are made conservative when pods are missing in the latest scrape
+type ValueAggregatorFunc func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64)
+
+func (f ValueAggregatorFunc) Aggregate(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+	lowerBoundTest, upperBoundTest, value := f(scrapeList, metric, scaleDownValue, scaleUpValue)
+	latestScrape := scrapeList[len(scrapeList)-1]
+	if latestScrape.MissingPods > 0 {
+		lowerBoundTest, upperBoundTest = conservativeBoundTests(value, latestScrape.ReadyPods, latestScrape.MissingPods, scaleDownValue, scaleUpValue)
+	}
+	return lowerBoundTest, upperBoundTest, value
+}
+
+var trimmedMeanAggregator = ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+	return calculateRobustMean(scrapeList, scaleDownValue, scaleUpValue, metric.TrimmedPercentage)
+})
+
+var aggregators = map[string]Aggregator{
+	AlgorithmDefault: AggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return testScrapeListDefault(scrapeList, metric.PercentageOfTestConditionFulfillment)
+	}),
+	"mean": ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return calculateMean(scrapeList, scaleDownValue, scaleUpValue)
+	}),
+	"median": ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return calculateMedian(scrapeList, scaleDownValue, scaleUpValue)
+	}),
+	"trimmedmean": trimmedMeanAggregator,
+	"percentile": ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return calculatePercentile(scrapeList, scaleDownValue, scaleUpValue, metric.Percentile)
+	}),
+	"max": ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return calculateMax(scrapeList, scaleDownValue, scaleUpValue)
+	}),
+	"min": ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return calculateMin(scrapeList, scaleDownValue, scaleUpValue)
+	}),
+	"ewma": ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		halfLife, err := time.ParseDuration(metric.EwmaHalfLife)
+		if err != nil {
+			log.Printf("Duration conversion error - ewmaHalfLife: %s", err.Error())
+			return false, false, 0
+		}
+		return calculateEwma(scrapeList, scaleDownValue, scaleUpValue, halfLife)
+	}),
+	"pid":             trimmedMeanAggregator,
+	"arimax":          trimmedMeanAggregator,
+	AlgorithmExternal: trimmedMeanAggregator,
+}
+
+// PipelineAlgorithm reads metric by its own pipeline instead of aggregating scrapes, so that its metrics have no scale values
+// and require fields returned by RequiredFields
+type PipelineAlgorithm struct {
+	RequiredFields func(metric scalingv1.AutoscalingDefinitionMetric) map[string]string
+}
+
+var pipelineAlgorithms = map[string]PipelineAlgorithm{
+	AlgorithmQueue: {RequiredFields: queueRequiredFields},
+}
+var aggregatorsMutex sync.RWMutex
+
+// RegisterAggregator makes algorithm available to algorithm field of metrics. Algorithm, which evaluates test results
+// by its own evaluator, registers also evaluator in autoscaler package.
+func RegisterAggregator(algorithm string, aggregator Aggregator) {
+	aggregatorsMutex.Lock()
+	defer aggregatorsMutex.Unlock()
+	aggregators[strings.ToLower(algorithm)] = aggregator
+}
+
+// RegisterPipelineAlgorithm makes algorithm without aggregator available to algorithm field of metrics. Pipeline, which reads
+// and evaluates metric of algorithm, is registered in autoscaler package.
+func RegisterPipelineAlgorithm(algorithm string, definition PipelineAlgorithm) {
+	aggregatorsMutex.Lock()
+	defer aggregatorsMutex.Unlock()
+	pipelineAlgorithms[strings.ToLower(algorithm)] = definition
+}
+
+func getPipelineAlgorithm(algorithm string) (PipelineAlgorithm, bool) {
+	aggregatorsMutex.RLock()
+	defer aggregatorsMutex.RUnlock()
+	definition, ok := pipelineAlgorithms[strings.ToLower(algorithm)]
+	return definition, ok
+}
+
+func GetAggregator(algorithm string) (Aggregator, error) {
+	aggregatorsMutex.RLock()
+	defer aggregatorsMutex.RUnlock()
+	aggregator, ok := aggregators[strings.ToLower(algorithm)]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("algorithm %s is not registered", algorithm))
+	}
+	return aggregator, nil
+}
+
+// SupportedAlgorithms returns algorithms with registered aggregator or pipeline
+func SupportedAlgorithms() []string {
+	aggregatorsMutex.RLock()
+	defer aggregatorsMutex.RUnlock()
+	var algorithms []string
+	for algorithm := range aggregators {
+		algorithms = append(algorithms, algorithm)
+	}
+	for algorithm := range pipelineAlgorithms {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+	return algorithms
+}
+
+func IsAlgorithmSupported(algorithm string) bool {
+	_, err := GetAggregator(algorithm)
+	return err == nil || IsPipelineAlgorithm(algorithm)
+}
+
+// IsDefaultAlgorithm returns true for unset or default algorithm, which only tests bounds of scrapes and aggregates no value
+func IsDefaultAlgorithm(algorithm string) bool {
+	return len(algorithm) <= 0 || strings.EqualFold(algorithm, AlgorithmDefault)
+}
+
+// IsPipelineAlgorithm returns true when metric of algorithm is read by its own pipeline instead of aggregated scrapes
+func IsPipelineAlgorithm(algorithm string) bool {
+	_, ok := getPipelineAlgorithm(algorithm)
+	return ok
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"testing"
+)
+
+func TestIsAlgorithmSupported(t *testing.T) {
+	for _, algorithm := range []string{"default", "TrimmedMean", "ewma", "arimax", "pid", "queue"} {
+		if !IsAlgorithmSupported(algorithm) {
+			t.Errorf("algorithm %s is not supported", algorithm)
+		}
+	}
+	if IsAlgorithmSupported("average") {
+		t.Error("unknown algorithm average is supported")
+	}
+}
+
+func TestValidateRequiredMetricFieldsRejectsUnknownAlgorithm(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		Name:           "cpu",
+		MetricType:     MetricTypePrometheus,
+		Algorithm:      "average",
+		ScaleValueType: "double",
+		ScaleDownValue: "20",
+		ScaleUpValue:   "50",
+	}
+	if err := validateRequiredMetricFields(metric); err == nil {
+		t.Error("metric with unknown algorithm passed validation")
+	}
+	metric.Algorithm = "median"
+	if err := validateRequiredMetricFields(metric); err != nil {
+		t.Errorf("metric with median algorithm failed validation: %s", err.Error())
+	}
+}
+
+func TestRegisteredAggregatorIsUsedByTest(t *testing.T) {
+	RegisterAggregator("Last", ValueAggregatorFunc(func(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+		return calculateMean(scrapeList[len(scrapeList)-1:], scaleDownValue, scaleUpValue)
+	}))
+	metric := scalingv1.AutoscalingDefinitionMetric{Algorithm: "last", ScaleDownValue: "20", ScaleUpValue: "50"}
+
+	lower, upper, value := testScrapeList(scalarScrapes(10, 10, 60), metric)
+
+	if lower || !upper || value != 60 {
+		t.Errorf("testScrapeList() = %t, %t, %f, expected false, true, 60", lower, upper, value)
+	}
+	if !IsAlgorithmSupported("last") {
+		t.Error("registered algorithm is not supported")
+	}
+}
+
+func TestRegisteredPipelineAlgorithm(t *testing.T) {
+	RegisterPipelineAlgorithm("Stream", PipelineAlgorithm{
+		RequiredFields: func(metric scalingv1.AutoscalingDefinitionMetric) map[string]string {
+			return map[string]string{"backlogQuery": metric.BacklogQuery}
+		},
+	})
+	if !IsAlgorithmSupported("stream") || !IsPipelineAlgorithm("STREAM") || !ContainsFold(SupportedAlgorithms(), "stream") {
+		t.Errorf("registered pipeline algorithm is not supported, supported algorithms: %v", SupportedAlgorithms())
+	}
+	if _, err := GetAggregator("stream"); err == nil {
+		t.Error("GetAggregator() of pipeline algorithm did not fail")
+	}
+
+	metric := scalingv1.AutoscalingDefinitionMetric{Name: "events", MetricType: MetricTypePrometheus, Algorithm: "stream"}
+	if err := validateRequiredMetricFields(metric); err == nil {
+		t.Error("metric of pipeline algorithm without its required field passed validation")
+	}
+	metric.BacklogQuery = "sum(events)"
+	if err := validateRequiredMetricFields(metric); err != nil {
+		t.Errorf("metric of pipeline algorithm without scale values failed validation: %s", err.Error())
+	}
+	metric.Algorithm = "queue"
+	if err := validateRequiredMetricFields(metric); err == nil {
+		t.Error("queue metric without rate queries passed validation")
+	}
+}
+
+func TestValidateMetricRejectsTotalValueScopeOfDefaultAlgorithm(t *testing.T) {
+	fldPath := field.NewPath("metric")
+	tests := []struct {
+		name      string
+		algorithm string
+		valid     bool
+	}{
+		{name: "unset algorithm"},
+		{name: "default algorithm", algorithm: "Default"},
+		{name: "mean algorithm", algorithm: "mean", valid: true},
+	}
+	for _, test := range tests {
+		metric := scalingv1.AutoscalingDefinitionMetric{
+			Name:            "cpu",
+			MetricType:      MetricTypePrometheus,
+			PrometheusQuery: "sum(rate(cpu[1m]))",
+			Algorithm:       test.algorithm,
+			ValueScope:      "total",
+			ScaleValueType:  "double",
+			ScaleDownValue:  "20",
+			ScaleUpValue:    "50",
+		}
+		rejected := false
+		for _, err := range ValidateMetric(metric, fldPath) {
+			if err.Field == "metric.valueScope" {
+				rejected = true
+			}
+		}
+		if rejected == test.valid {
+			t.Errorf("%s: total value scope rejected %t, expected %t", test.name, rejected, !test.valid)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"fmt"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var supportedScaleValueTypes = []string{"integer", "double", "boolean", "time", "string"}
+var supportedValueScopes = []string{"perReplica", "total"}
+var supportedExternalProtocols = []string{"http", "grpc"}
+
+// ValidateMetric extends required fields check with semantic validation of metric, which would otherwise
+// fail at runtime or be silently replaced by FillEmptyMetricFields
+func ValidateMetric(metric scalingv1.AutoscalingDefinitionMetric, fldPath *field.Path) field.ErrorList {
+	allErrs := requiredMetricFieldErrors(metric, fldPath)
+	algorithm := strings.ToUpper(metric.Algorithm)
+
+	if len(metric.Algorithm) > 0 && !IsAlgorithmSupported(metric.Algorithm) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("algorithm"), metric.Algorithm, SupportedAlgorithms()))
+	}
+	if len(metric.ValueScope) > 0 && !ContainsFold(supportedValueScopes, metric.ValueScope) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("valueScope"), metric.ValueScope, supportedValueScopes))
+	}
+	if IsTotalValueScope(metric) && IsDefaultAlgorithm(metric.Algorithm) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("valueScope"), metric.ValueScope, "total value scope requires algorithm aggregating metric value, default algorithm aggregates none"))
+	}
+	if !IsPipelineAlgorithm(metric.Algorithm) {
+		if len(metric.PrometheusQuery) <= 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("prometheusQuery"), ""))
+		}
+		allErrs = append(allErrs, validateScaleValues(metric, fldPath)...)
+	}
+	if _, err := GetMetricSource(metric.MetricType); len(metric.MetricType) > 0 && err != nil {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("metricType"), metric.MetricType, RegisteredMetricTypes()))
+	}
+	if strings.ToLower(metric.MetricType) == MetricTypePrometheus && len(metric.PrometheusPath) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("prometheusPath"), ""))
+	}
+
+	if metric.NumOfTests < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("numOfTests"), metric.NumOfTests, "must be greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, validatePercentage(metric.TrimmedPercentage, fldPath.Child("trimmedPercentage"))...)
+	allErrs = append(allErrs, validatePercentage(metric.Percentile, fldPath.Child("percentile"))...)
+	allErrs = append(allErrs, validatePercentage(metric.PercentageOfTestConditionFulfillment, fldPath.Child("percentageOfTestConditionFulfillment"))...)
+
+	scrapeInterval, errs := ValidateDuration(metric.ScrapeInterval, "1s", fldPath.Child("scrapeInterval"))
+	allErrs = append(allErrs, errs...)
+	testInterval, errs := ValidateDuration(metric.TestInterval, "1m", fldPath.Child("testInterval"))
+	allErrs = append(allErrs, errs...)
+	if len(errs) == 0 && scrapeInterval > testInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("scrapeInterval"), metric.ScrapeInterval, fmt.Sprintf("must not be longer than testInterval (%s)", testInterval)))
+	}
+	_, errs = ValidateDuration(metric.EwmaHalfLife, "30s", fldPath.Child("ewmaHalfLife"))
+	allErrs = append(allErrs, errs...)
+	_, errs = ValidateDuration(metric.TargetDrainTime, "5m", fldPath.Child("targetDrainTime"))
+	allErrs = append(allErrs, errs...)
+	_, errs = ValidateDuration(metric.PanicStabilizationPeriod, "2m", fldPath.Child("panicStabilizationPeriod"))
+	allErrs = append(allErrs, errs...)
+
+	allErrs = append(allErrs, validateCoefficients(metric.AutoregresionDegree, metric.AutoregressionCoefficients, fldPath.Child("autoregresionDegree"), fldPath.Child("autoregressionCoefficients"))...)
+	allErrs = append(allErrs, validateCoefficients(metric.MovingAverageDegree, metric.MovingAverageCoefficients, fldPath.Child("movingAverageDegree"), fldPath.Child("movingAverageCoefficients"))...)
+	allErrs = append(allErrs, validateCoefficients(metric.SeasonalAutoregressionDegree, metric.SeasonalAutoregressionCoefficients, fldPath.Child("seasonalAutoregressionDegree"), fldPath.Child("seasonalAutoregressionCoefficients"))...)
+	allErrs = append(allErrs, validateCoefficients(metric.SeasonalMovingAverageDegree, metric.SeasonalMovingAverageCoefficients, fldPath.Child("seasonalMovingAverageDegree"), fldPath.Child("seasonalMovingAverageCoefficients"))...)
+	if metric.DifferencingOrder < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("differencingOrder"), metric.DifferencingOrder, "must be greater than or equal to 0"))
+	}
+	if metric.SeasonalDifferencingOrder < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("seasonalDifferencingOrder"), metric.SeasonalDifferencingOrder, "must be greater than or equal to 0"))
+	}
+	if metric.SeasonalPeriod < 0 || metric.SeasonalPeriod == 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("seasonalPeriod"), metric.SeasonalPeriod, "must be greater than 1"))
+	}
+	if metric.SeasonalPeriod == 0 && (metric.SeasonalAutoregressionDegree > 0 || metric.SeasonalMovingAverageDegree > 0 || metric.SeasonalDifferencingOrder > 0) {
+		allErrs = append(allErrs, field.Required(fldPath.Child("seasonalPeriod"), "required by seasonal terms"))
+	}
+	allErrs = append(allErrs, validateFloat(metric.Intercept, fldPath.Child("intercept"))...)
+	allErrs = append(allErrs, validateFloat(metric.ExogenousRegressorCoefficient, fldPath.Child("exogenousRegressorCoefficient"))...)
+	allErrs = append(allErrs, validateFloat(metric.ExogenousRegressorMaxValue, fldPath.Child("exogenousRegressorMaxValue"))...)
+	allErrs = append(allErrs, validateExogenousRegressors(metric, fldPath)...)
+	if algorithm == "ARIMAX" && len(metric.ExogenousRegressors) <= 0 {
+		if len(metric.ExogenousRegressorQuery) <= 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("exogenousRegressorQuery"), "required by arimax algorithm, when exogenousRegressors are not set"))
+		}
+		if len(metric.ExogenousRegressorMaxValue) <= 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("exogenousRegressorMaxValue"), "required by arimax algorithm"))
+		}
+	}
+
+	if algorithm == "EXTERNAL" && len(metric.ExternalEndpoint) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("externalEndpoint"), "required by external algorithm"))
+	}
+	if algorithm == "EXTERNAL" && len(metric.ExogenousRegressorQuery) > 0 && len(metric.ExogenousRegressorMaxValue) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("exogenousRegressorMaxValue"), "required by exogenous regressor of external algorithm"))
+	}
+	if len(metric.ExternalProtocol) > 0 && !ContainsFold(supportedExternalProtocols, metric.ExternalProtocol) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("externalProtocol"), metric.ExternalProtocol, supportedExternalProtocols))
+	}
+	_, errs = ValidateDuration(metric.ExternalTimeout, "5s", fldPath.Child("externalTimeout"))
+	allErrs = append(allErrs, errs...)
+	if metric.ExternalHistoryLength < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("externalHistoryLength"), metric.ExternalHistoryLength, "must be greater than or equal to 0"))
+	}
+
+	if algorithm == "PID" && len(metric.TargetValue) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetValue"), "required by pid algorithm"))
+	}
+	allErrs = append(allErrs, validateFloat(metric.TargetValue, fldPath.Child("targetValue"))...)
+	allErrs = append(allErrs, validateFloat(metric.ProportionalGain, fldPath.Child("proportionalGain"))...)
+	allErrs = append(allErrs, validateFloat(metric.IntegralGain, fldPath.Child("integralGain"))...)
+	allErrs = append(allErrs, validateFloat(metric.DerivativeGain, fldPath.Child("derivativeGain"))...)
+	if metric.MaxReplicaDelta < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicaDelta"), metric.MaxReplicaDelta, "must be greater than or equal to 0"))
+	}
+
+	allErrs = append(allErrs, validateFloat(metric.PanicThreshold, fldPath.Child("panicThreshold"))...)
+	if panicThreshold, err := strconv.ParseFloat(metric.PanicThreshold, 64); err == nil && panicThreshold <= 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("panicThreshold"), metric.PanicThreshold, "must be greater than 1"))
+	}
+	if metric.PanicWindow < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("panicWindow"), metric.PanicWindow, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateScaleValues(metric scalingv1.AutoscalingDefinitionMetric, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(metric.ScaleValueType) <= 0 || len(metric.ScaleDownValue) <= 0 || len(metric.ScaleUpValue) <= 0 {
+		return allErrs
+	}
+	downPath := fldPath.Child("scaleDownValue")
+	upPath := fldPath.Child("scaleUpValue")
+	switch strings.ToUpper(metric.ScaleValueType) {
+	case "INTEGER":
+		downValue, downErr := strconv.ParseInt(metric.ScaleDownValue, 10, 64)
+		if downErr != nil {
+			allErrs = append(allErrs, field.Invalid(downPath, metric.ScaleDownValue, "must be an integer"))
+		}
+		upValue, upErr := strconv.ParseInt(metric.ScaleUpValue, 10, 64)
+		if upErr != nil {
+			allErrs = append(allErrs, field.Invalid(upPath, metric.ScaleUpValue, "must be an integer"))
+		}
+		if downErr == nil && upErr == nil && downValue > upValue {
+			allErrs = append(allErrs, field.Invalid(downPath, metric.ScaleDownValue, "must be less than or equal to scaleUpValue"))
+		}
+	case "DOUBLE":
+		downValue, downErr := strconv.ParseFloat(metric.ScaleDownValue, 64)
+		if downErr != nil {
+			allErrs = append(allErrs, field.Invalid(downPath, metric.ScaleDownValue, "must be a number"))
+		}
+		upValue, upErr := strconv.ParseFloat(metric.ScaleUpValue, 64)
+		if upErr != nil {
+			allErrs = append(allErrs, field.Invalid(upPath, metric.ScaleUpValue, "must be a number"))
+		}
+		if downErr == nil && upErr == nil && downValue > upValue {
+			allErrs = append(allErrs, field.Invalid(downPath, metric.ScaleDownValue, "must be less than or equal to scaleUpValue"))
+		}
+	case "TIME":
+		downTime, downErr := time.Parse(time.RFC3339, metric.ScaleDownValue)
+		if downErr != nil {
+			allErrs = append(allErrs, field.Invalid(downPath, metric.ScaleDownValue, "must be a RFC3339 time"))
+		}
+		upTime, upErr := time.Parse(time.RFC3339, metric.ScaleUpValue)
+		if upErr != nil {
+			allErrs = append(allErrs, field.Invalid(upPath, metric.ScaleUpValue, "must be a RFC3339 time"))
+		}
+		if downErr == nil && upErr == nil && downTime.After(upTime) {
+			allErrs = append(allErrs, field.Invalid(downPath, metric.ScaleDownValue, "must not be after scaleUpValue"))
+		}
+	case "BOOLEAN", "STRING":
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("scaleValueType"), metric.ScaleValueType, supportedScaleValueTypes))
+	}
+	return allErrs
+}
+
+func validateCoefficients(degree int, coefficients []string, degreePath *field.Path, coefficientsPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if degree < 0 {
+		allErrs = append(allErrs, field.Invalid(degreePath, degree, "must be greater than or equal to 0"))
+	}
+	if len(coefficients) > 0 && len(coefficients) != degree {
+		allErrs = append(allErrs, field.Invalid(coefficientsPath, coefficients, fmt.Sprintf("must contain %d coefficients to match degree", degree)))
+	}
+	for i, coef := range coefficients {
+		if _, err := strconv.ParseFloat(coef, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(coefficientsPath.Index(i), coef, "must be a number"))
+		}
+	}
+	return allErrs
+}
+
+func validatePercentage(value int, fldPath *field.Path) field.ErrorList {
+	if value < 0 || value > 100 {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be between 0 and 100")}
+	}
+	return field.ErrorList{}
+}
+
+func validateFloat(value string, fldPath *field.Path) field.ErrorList {
+	if len(value) <= 0 {
+		return field.ErrorList{}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be a number")}
+	}
+	return field.ErrorList{}
+}
+
+// ValidateDuration returns parsed duration, or parsed default value when duration is not set.
+// It is shared by validation of metrics and of definition.
+func ValidateDuration(value string, defaultValue string, fldPath *field.Path) (time.Duration, field.ErrorList) {
+	if len(value) <= 0 {
+		duration, _ := time.ParseDuration(defaultValue)
+		return duration, field.ErrorList{}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	if duration < 0 {
+		return 0, field.ErrorList{field.Invalid(fldPath, value, "must not be negative")}
+	}
+	return duration, field.ErrorList{}
+}
+
+// ContainsFold reports whether values contain value under case folding
+func ContainsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
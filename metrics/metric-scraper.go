@@ -1,29 +1,27 @@
 package metrics
 
 import (
-	"context"
-	"custom-hpa/model"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/util"
 	"errors"
-	"fmt"
-	promApi "github.com/prometheus/client_golang/api"
-	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	model2 "github.com/prometheus/common/model"
 	"log"
+	"strings"
 	"time"
 )
 
 type ScrapeResultChannel struct {
 	scrapedMetricsChannel chan []MetricValidateResult
 	scrapeInterval        chan bool
+	clock                 util.Clock
 }
 
-func MakeScrape(metric model.AutoscalingDefinitionMetric) (ScrapeResultChannel, error) {
+func MakeScrape(metric scalingv1.AutoscalingDefinitionMetric, providers TargetProviders) (ScrapeResultChannel, error) {
 	err := validateRequiredMetricFields(metric)
 	if err != nil {
 		return ScrapeResultChannel{}, err
 	}
-	fillEmptyMetricFields(&metric)
+	FillEmptyMetricFields(&metric)
 	scrapeDuration, err := time.ParseDuration(metric.ScrapeInterval)
 	if err != nil {
 		return ScrapeResultChannel{}, err
@@ -32,31 +30,47 @@ func MakeScrape(metric model.AutoscalingDefinitionMetric) (ScrapeResultChannel,
 	if err != nil {
 		return ScrapeResultChannel{}, err
 	}
-	scrapedMetricsChannel, scrapeInterval := ScrapeMetrics(metric, testDuration, scrapeDuration)
+	scrapedMetricsChannel, scrapeInterval := ScrapeMetrics(metric, testDuration, scrapeDuration, providers)
 	return ScrapeResultChannel{
 		scrapedMetricsChannel: scrapedMetricsChannel,
 		scrapeInterval:        scrapeInterval,
+		clock:                 util.ClockOrReal(providers.Clock),
 	}, nil
 }
 
-func ScrapeMetrics(metric model.AutoscalingDefinitionMetric, testDuration time.Duration, scrapeDuration time.Duration) (scrapedMetricsChannel chan []MetricValidateResult, scrapeInterval chan bool) {
+func ScrapeMetrics(metric scalingv1.AutoscalingDefinitionMetric, testDuration time.Duration, scrapeDuration time.Duration, providers TargetProviders) (scrapedMetricsChannel chan []MetricValidateResult, scrapeInterval chan bool) {
 	maxNumOfScrapes := int64(testDuration) / int64(scrapeDuration)
 	var scrapesCounter int64 = 0
 	scrapedMetrics := MetricValidateResultMap{
 		Name:        metric.Name,
 		ScrapedList: make([]MetricValidateResult, 0),
 	}
-	scrapedMetricsChannel = make(chan []MetricValidateResult)
+	scrapedMetricsChannel = make(chan []MetricValidateResult, 1)
+	clock := util.ClockOrReal(providers.Clock)
 
-	scrapeInterval = util.SetInterval(func() {
-		result, err := ScrapeMetric(metric)
+	scrapeInterval = clock.SetInterval(func() {
+		var podStates map[string]PodState
+		if providers.PodStates != nil {
+			var err error
+			if podStates, err = providers.PodStates(); err != nil {
+				log.Printf("Pod state error: %s", err.Error())
+			}
+		}
+		var replicas = 0
+		if RequiresReplicaCount(metric) && providers.ReplicaCount != nil {
+			var err error
+			if replicas, err = providers.ReplicaCount(); err != nil {
+				log.Printf("Replica count error: %s", err.Error())
+			}
+		}
+		result, err := ScrapeMetric(metric, podStates, replicas, clock.Now())
 		if err == nil && result.IsMetricValid {
 			scrapedMetrics.ScrapedList = append(scrapedMetrics.ScrapedList, result)
 		}
 		scrapesCounter++
 		if scrapesCounter >= maxNumOfScrapes {
 			scrapesCounter = 0
-			scrapedMetricsChannel <- scrapedMetrics.ScrapedList
+			publishScrapes(scrapedMetricsChannel, scrapedMetrics.ScrapedList)
 			scrapedMetrics.ScrapedList = nil
 		}
 	}, scrapeDuration, false)
@@ -64,50 +78,103 @@ func ScrapeMetrics(metric model.AutoscalingDefinitionMetric, testDuration time.D
 	return
 }
 
-func ScrapeMetric(metric model.AutoscalingDefinitionMetric) (MetricValidateResult, error) {
+// publishScrapes hands scrapes of one test interval over to tester without blocking scrape interval, so that scraper and tester
+// do not wait for each other, when their timers fire one after another. Scrapes not taken by tester yet are replaced by newer ones.
+func publishScrapes(scrapedMetricsChannel chan []MetricValidateResult, scrapes []MetricValidateResult) {
+	for {
+		select {
+		case scrapedMetricsChannel <- scrapes:
+			return
+		default:
+		}
+		select {
+		case stale := <-scrapedMetricsChannel:
+			log.Printf("Dropping %d scrapes of metric not taken by tester", len(stale))
+		default:
+		}
+	}
+}
+
+func ScrapeMetric(metric scalingv1.AutoscalingDefinitionMetric, podStates map[string]PodState, replicas int, at time.Time) (MetricValidateResult, error) {
 	var result MetricValidateResult
-	value, err := ReadMetric(metric.PrometheusPath, metric.PrometheusQuery)
+	value, err := ReadMetric(metric.MetricType, MetricSourceSpec{Address: metric.PrometheusPath, Query: metric.PrometheusQuery, Time: at})
 	if err != nil {
 		log.Printf("Error: %s", err.Error())
 		result = MetricValidateResult{IsMetricValid: false, MetricName: metric.Name}
 		return result, err
 	}
-	result, err = ValidateMetricBounds(value, metric)
+	return EvaluateScrapedValue(value, metric, podStates, replicas)
+}
+
+// EvaluateScrapedValue validates bounds of value read from metric source, it is shared by scraper and simulator.
+func EvaluateScrapedValue(value model2.Value, metric scalingv1.AutoscalingDefinitionMetric, podStates map[string]PodState, replicas int) (MetricValidateResult, error) {
+	var result MetricValidateResult
+	var err error
+	if IsTotalValueScope(metric) {
+		value, err = normalizeMetricValue(value, replicas)
+		if err != nil {
+			log.Printf("Error: %s", err.Error())
+			result = MetricValidateResult{IsMetricValid: false, MetricName: metric.Name}
+			return result, err
+		}
+	}
+	result, err = ValidateMetricBounds(value, metric, podStates)
 	if err != nil {
 		log.Printf("Error: %s", err.Error())
 		result = MetricValidateResult{IsMetricValid: false, MetricName: metric.Name}
 		return result, err
 	}
 	result.IsMetricValid = true
+	result.Replicas = replicas
 	return result, nil
 }
 
-func ReadMetric(PrometheusPath string, PrometheusQuery string) (model2.Value, error) {
-	if len(PrometheusPath) <= 0 || len(PrometheusQuery) <= 0 {
-		return nil, errors.New("prometheus query or path should not be null")
-	}
-	return readPrometheusMetrics(PrometheusPath, PrometheusQuery)
+func IsTotalValueScope(metric scalingv1.AutoscalingDefinitionMetric) bool {
+	return strings.ToUpper(metric.ValueScope) == "TOTAL"
 }
 
-func readPrometheusMetrics(address string, query string) (model2.Value, error) {
-	client := client(address)
-	api := v1.NewAPI(client)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	value, warnings, err := api.Query(ctx, query, time.Now())
-	if err != nil {
-		return nil, err
+// RequiresReplicaCount returns true when scrapes of metric have to hold current replicas of target
+func RequiresReplicaCount(metric scalingv1.AutoscalingDefinitionMetric) bool {
+	return IsTotalValueScope(metric) || strings.ToLower(metric.Algorithm) == AlgorithmExternal
+}
+
+// normalizeMetricValue divides total metric value by number of replicas to compare it with per replica bounds
+func normalizeMetricValue(value model2.Value, replicas int) (model2.Value, error) {
+	if replicas <= 0 {
+		return nil, errors.New("cannot normalize total metric value without running replicas")
 	}
-	if len(warnings) > 0 {
-		fmt.Printf("Warnings: %v\n", warnings)
+	switch value.Type() {
+	case model2.ValScalar:
+		if res, ok := value.(*model2.Scalar); ok {
+			return &model2.Scalar{Value: res.Value / model2.SampleValue(replicas), Timestamp: res.Timestamp}, nil
+		}
+	case model2.ValVector:
+		if res, ok := value.(model2.Vector); ok {
+			normalized := make(model2.Vector, 0, res.Len())
+			for _, sample := range res {
+				normalized = append(normalized, &model2.Sample{Metric: sample.Metric, Value: sample.Value / model2.SampleValue(replicas), Timestamp: sample.Timestamp})
+			}
+			return normalized, nil
+		}
 	}
-	return value, nil
+	return nil, errors.New("total value scope is supported only for scalar and vector metrics")
 }
 
-func client(address string) promApi.Client {
-	client, err := promApi.NewClient(promApi.Config{Address: address})
-	if err != nil {
-		panic(err)
+// multiplyMetricValue multiplies samples of scalar and vector metric value by factor
+func multiplyMetricValue(value model2.Value, factor float64) (model2.Value, error) {
+	switch value.Type() {
+	case model2.ValScalar:
+		if res, ok := value.(*model2.Scalar); ok {
+			return &model2.Scalar{Value: res.Value * model2.SampleValue(factor), Timestamp: res.Timestamp}, nil
+		}
+	case model2.ValVector:
+		if res, ok := value.(model2.Vector); ok {
+			multiplied := make(model2.Vector, 0, res.Len())
+			for _, sample := range res {
+				multiplied = append(multiplied, &model2.Sample{Metric: sample.Metric, Value: sample.Value * model2.SampleValue(factor), Timestamp: sample.Timestamp})
+			}
+			return multiplied, nil
+		}
 	}
-	return client
+	return nil, errors.New("only scalar and vector metric values can be multiplied")
 }
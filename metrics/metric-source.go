@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model2 "github.com/prometheus/common/model"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MetricTypePrometheus = "prometheus"
+	MetricTypeMemory     = "memory"
+)
+
+var metricSourceTimeout = 30 * time.Second
+
+// MetricSourceSpec describes single query evaluated by metric source, Address is interpreted by source
+type MetricSourceSpec struct {
+	Address string
+	Query   string
+	Time    time.Time
+}
+
+// MetricSource reads samples of query from metric backend, returned value is scalar or vector as produced by Prometheus
+type MetricSource interface {
+	Query(ctx context.Context, spec MetricSourceSpec) (model2.Value, error)
+}
+
+var metricSources = map[string]MetricSource{
+	MetricTypePrometheus: PrometheusSource{},
+}
+var metricSourcesMutex sync.RWMutex
+
+// RegisterMetricSource makes source available to metrics with given metricType, registered source replaces previous one
+func RegisterMetricSource(metricType string, source MetricSource) {
+	metricSourcesMutex.Lock()
+	defer metricSourcesMutex.Unlock()
+	metricSources[strings.ToLower(metricType)] = source
+}
+
+// GetMetricSource returns source registered for metricType, empty metricType resolves to Prometheus
+func GetMetricSource(metricType string) (MetricSource, error) {
+	if len(metricType) <= 0 {
+		metricType = MetricTypePrometheus
+	}
+	metricSourcesMutex.RLock()
+	defer metricSourcesMutex.RUnlock()
+	source, ok := metricSources[strings.ToLower(metricType)]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("metric source %s is not registered", metricType))
+	}
+	return source, nil
+}
+
+func RegisteredMetricTypes() []string {
+	metricSourcesMutex.RLock()
+	defer metricSourcesMutex.RUnlock()
+	var metricTypes []string
+	for metricType := range metricSources {
+		metricTypes = append(metricTypes, metricType)
+	}
+	sort.Strings(metricTypes)
+	return metricTypes
+}
+
+// ReadMetric evaluates query at given time by source registered for metricType
+func ReadMetric(metricType string, spec MetricSourceSpec) (model2.Value, error) {
+	source, err := GetMetricSource(metricType)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), metricSourceTimeout)
+	defer cancel()
+	return source.Query(ctx, spec)
+}
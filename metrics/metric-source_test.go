@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	model2 "github.com/prometheus/common/model"
+	"testing"
+	"time"
+)
+
+func TestGetMetricSource(t *testing.T) {
+	source, err := GetMetricSource("")
+	if _, ok := source.(PrometheusSource); err != nil || !ok {
+		t.Errorf("GetMetricSource(\"\") = %T, %v, expected Prometheus source", source, err)
+	}
+	if _, err := GetMetricSource("unknown"); err == nil {
+		t.Error("GetMetricSource(\"unknown\") did not fail")
+	}
+	memory := NewMemorySource()
+	RegisterMetricSource(MetricTypeMemory, memory)
+	if source, err := GetMetricSource("Memory"); err != nil || source != memory {
+		t.Errorf("GetMetricSource(\"Memory\") = %v, %v, expected registered memory source", source, err)
+	}
+}
+
+func TestMemorySourceQuery(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := NewMemorySource()
+	source.AddSample("load", start.Add(time.Minute), 20)
+	source.AddSample("load", start, 10)
+	tests := []struct {
+		name          string
+		query         string
+		at            time.Time
+		expectedValue float64
+		expectedEmpty bool
+		expectedError bool
+	}{
+		{name: "before the first sample", query: "load", at: start.Add(-time.Second), expectedEmpty: true},
+		{name: "at sample", query: "load", at: start, expectedValue: 10},
+		{name: "between samples", query: "load", at: start.Add(59 * time.Second), expectedValue: 10},
+		{name: "after the last sample", query: "load", at: start.Add(time.Hour), expectedValue: 20},
+		{name: "unknown query", query: "latency", at: start, expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := source.Query(context.Background(), MetricSourceSpec{Query: test.query, Time: test.at})
+			if test.expectedError {
+				if err == nil {
+					t.Errorf("Query() = %v, expected error", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Query() failed: %s", err.Error())
+			}
+			if test.expectedEmpty {
+				if vector, ok := value.(model2.Vector); !ok || len(vector) > 0 {
+					t.Errorf("Query() = %v, expected empty vector", value)
+				}
+				return
+			}
+			if scalar, ok := value.(*model2.Scalar); !ok || float64(scalar.Value) != test.expectedValue {
+				t.Errorf("Query() = %v, expected %f", value, test.expectedValue)
+			}
+		})
+	}
+}
+
+func TestScrapeMetricFromMemorySource(t *testing.T) {
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := NewMemorySource()
+	source.AddSample("load", at, 80)
+	RegisterMetricSource(MetricTypeMemory, source)
+	metric := scalingv1.AutoscalingDefinitionMetric{
+		Name:            "load",
+		MetricType:      MetricTypeMemory,
+		PrometheusQuery: "load",
+		ScaleValueType:  "double",
+		ScaleDownValue:  "20",
+		ScaleUpValue:    "50",
+	}
+
+	result, err := ScrapeMetric(metric, nil, 0, at)
+
+	if err != nil {
+		t.Fatalf("ScrapeMetric() failed: %s", err.Error())
+	}
+	if !result.IsMetricValid || !result.UpperBoundPassed || result.LowerBoundPassed {
+		t.Errorf("ScrapeMetric() = %+v, expected valid result passing upper bound", result)
+	}
+}
@@ -1,14 +1,13 @@
 package metrics
 
 import (
-	"custom-hpa/model"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"custom-hpa/util"
 	model2 "github.com/prometheus/common/model"
 	"log"
 	"math"
 	"sort"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -23,20 +22,24 @@ type TestResult struct {
 	UpperBoundTestPassed bool
 	MetricName           string
 	Value                float64
+	IsValid              bool
+	TotalValue           float64
+	Replicas             int
+	ScrapeCount          int
 }
 
 // public functions
-func MakeTest(metric model.AutoscalingDefinitionMetric, scrapeResultChannel ScrapeResultChannel) (TestResultsChannel, error) {
+func MakeTest(metric scalingv1.AutoscalingDefinitionMetric, scrapeResultChannel ScrapeResultChannel) (TestResultsChannel, error) {
 	err := validateRequiredMetricFields(metric)
 	if err != nil {
 		return TestResultsChannel{}, err
 	}
-	fillEmptyMetricFields(&metric)
+	FillEmptyMetricFields(&metric)
 	testDuration, err := time.ParseDuration(metric.TestInterval)
 	if err != nil {
 		return TestResultsChannel{}, err
 	}
-	testResultsChannel, testInterval := testSingleMetric(metric, testDuration, scrapeResultChannel.scrapedMetricsChannel)
+	testResultsChannel, testInterval := testSingleMetric(metric, testDuration, scrapeResultChannel.scrapedMetricsChannel, util.ClockOrReal(scrapeResultChannel.clock))
 	return TestResultsChannel{
 		TestResultsChannel: testResultsChannel,
 		ScrapeInterval:     scrapeResultChannel.scrapeInterval,
@@ -44,7 +47,7 @@ func MakeTest(metric model.AutoscalingDefinitionMetric, scrapeResultChannel Scra
 	}, nil
 }
 
-func TestSingleValueBounds(metric model.AutoscalingDefinitionMetric, value float64) (bool, bool) {
+func TestSingleValueBounds(metric scalingv1.AutoscalingDefinitionMetric, value float64) (bool, bool) {
 	scaleDownValue, err := strconv.ParseFloat(metric.ScaleDownValue, 64)
 	if err != nil {
 		log.Printf("Float conversion error - scaleUpValue: %s", err.Error())
@@ -60,22 +63,53 @@ func TestSingleValueBounds(metric model.AutoscalingDefinitionMetric, value float
 	return lowerBoundTest, upperBoundTest
 }
 
+// TestScrapes aggregates scrapes of one test interval into test result, it is shared by tester and simulator.
+func TestScrapes(scrapes []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric) TestResult {
+	lowerBoundTest, upperBoundTest, value := testScrapeList(scrapes, metric)
+	var testResult = TestResult{
+		LowerBoundTestPassed: lowerBoundTest,
+		UpperBoundTestPassed: upperBoundTest,
+		MetricName:           metric.Name,
+		Value:                value,
+		IsValid:              len(scrapes) > 0,
+		ScrapeCount:          len(scrapes),
+	}
+	if len(scrapes) > 0 {
+		testResult.Replicas = scrapes[len(scrapes)-1].Replicas
+		_, _, testResult.TotalValue = testScrapeList(scrapeTotals(scrapes), metric)
+	}
+	return testResult
+}
+
+// scrapeTotals multiplies values of every scrape by replicas of the scrape, so that total value is aggregated from totals
+// of scrapes made while replicas changed
+func scrapeTotals(scrapes []MetricValidateResult) []MetricValidateResult {
+	var result []MetricValidateResult
+	for _, scrape := range scrapes {
+		total := scrape
+		total.Value = nil
+		for _, value := range scrape.Value {
+			totalValue, err := multiplyMetricValue(value, float64(scrape.Replicas))
+			if err != nil {
+				log.Printf("Error: %s", err.Error())
+				continue
+			}
+			total.Value = append(total.Value, totalValue)
+		}
+		result = append(result, total)
+	}
+	return result
+}
+
 // private functions
-func testSingleMetric(metric model.AutoscalingDefinitionMetric, testDuration time.Duration, scrapedMetricsChannel chan []MetricValidateResult) (testResultsChannel chan TestResult, testInterval chan bool) {
+func testSingleMetric(metric scalingv1.AutoscalingDefinitionMetric, testDuration time.Duration, scrapedMetricsChannel chan []MetricValidateResult, clock util.Clock) (testResultsChannel chan TestResult, testInterval chan bool) {
 	maxNumOfTests := metric.NumOfTests
 	var testCounter = 0
 	testResultsChannel = make(chan TestResult)
-	testInterval = util.SetInterval(func() {
+	testInterval = clock.SetInterval(func() {
 		select {
 		case scrapes := <-scrapedMetricsChannel:
-			lowerBoundTest, upperBoundTest, value := testScrapeList(scrapes, metric)
-			var testResult = TestResult{
-				LowerBoundTestPassed: lowerBoundTest,
-				UpperBoundTestPassed: upperBoundTest,
-				MetricName:           metric.Name,
-				Value:                value,
-			}
-			testResultsChannel <- testResult
+			testResultsChannel <- TestScrapes(scrapes, metric)
 		}
 		testCounter++
 		if testCounter >= maxNumOfTests {
@@ -85,7 +119,7 @@ func testSingleMetric(metric model.AutoscalingDefinitionMetric, testDuration tim
 	return
 }
 
-func testScrapeList(scrapeList []MetricValidateResult, metric model.AutoscalingDefinitionMetric) (bool, bool, float64) {
+func testScrapeList(scrapeList []MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric) (bool, bool, float64) {
 	if scrapeList == nil || len(scrapeList) <= 0 {
 		log.Printf("No scrapes found.")
 		return false, false, 0
@@ -100,18 +134,12 @@ func testScrapeList(scrapeList []MetricValidateResult, metric model.AutoscalingD
 		log.Printf("Float conversion error - scaleDownValue: %s", err.Error())
 		return false, false, 0
 	}
-	switch strings.ToUpper(metric.Algorithm) {
-	case "MEAN":
-		return calculateMean(scrapeList, scaleDownValue, scaleUpValue)
-	case "MEDIAN":
-		return calculateMedian(scrapeList, scaleDownValue, scaleUpValue)
-	case "TRIMMEDMEAN":
-		return calculateRobustMean(scrapeList, scaleDownValue, scaleUpValue, metric.TrimmedPercentage)
-	case "ARIMAX":
-		return calculateRobustMean(scrapeList, scaleDownValue, scaleUpValue, metric.TrimmedPercentage)
-	default:
-		return testScrapeListDefault(scrapeList, metric.PercentageOfTestConditionFulfillment)
+	aggregator, err := GetAggregator(metric.Algorithm)
+	if err != nil {
+		log.Printf("Aggregation error: %s", err.Error())
+		return false, false, 0
 	}
+	return aggregator.Aggregate(scrapeList, metric, scaleDownValue, scaleUpValue)
 }
 
 func flattenScrapeList(scrapeList []MetricValidateResult) []MetricValidateResult {
@@ -154,7 +182,7 @@ func calculateRobustMean(scrapeList []MetricValidateResult, scaleDownValue float
 		return calculateMean(flatScrapeList, scaleDownValue, scaleUpValue)
 	}
 	flatScrapeList = flatScrapeList[k:]
-	flatScrapeList = flatScrapeList[:len(flatScrapeList)-k-1]
+	flatScrapeList = flatScrapeList[:len(flatScrapeList)-k]
 	return calculateMean(flatScrapeList, scaleDownValue, scaleUpValue)
 
 }
@@ -173,7 +201,7 @@ func calculateMedian(scrapeList []MetricValidateResult, scaleDownValue float64,
 		return false
 	})
 	var median float64
-	if len(scrapeList)%2 == 1 {
+	if len(flatScrapeList)%2 == 1 {
 		median = float64(flatScrapeList[len(flatScrapeList)/2].Value[0].(*model2.Scalar).Value)
 	} else {
 		a1 := float64(flatScrapeList[len(flatScrapeList)/2-1].Value[0].(*model2.Scalar).Value)
@@ -185,6 +213,90 @@ func calculateMedian(scrapeList []MetricValidateResult, scaleDownValue float64,
 	return lowerBoundTest, upperBoundTest, median
 }
 
+func calculatePercentile(scrapeList []MetricValidateResult, scaleDownValue float64, scaleUpValue float64, percentile int) (bool, bool, float64) {
+	values := flattenScrapeValues(scrapeList)
+	if len(values) <= 0 {
+		return false, false, 0
+	}
+	sort.Float64s(values)
+	rank := float64(percentile) / 100.0 * float64(len(values)-1)
+	lowerIndex := int(math.Floor(rank))
+	upperIndex := int(math.Ceil(rank))
+	value := values[lowerIndex] + (values[upperIndex]-values[lowerIndex])*(rank-float64(lowerIndex))
+	lowerBoundTest := value <= scaleDownValue
+	upperBoundTest := value >= scaleUpValue
+	return lowerBoundTest, upperBoundTest, value
+}
+
+func calculateMax(scrapeList []MetricValidateResult, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+	values := flattenScrapeValues(scrapeList)
+	if len(values) <= 0 {
+		return false, false, 0
+	}
+	max := values[0]
+	for _, value := range values {
+		max = math.Max(max, value)
+	}
+	lowerBoundTest := max <= scaleDownValue
+	upperBoundTest := max >= scaleUpValue
+	return lowerBoundTest, upperBoundTest, max
+}
+
+func calculateMin(scrapeList []MetricValidateResult, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
+	values := flattenScrapeValues(scrapeList)
+	if len(values) <= 0 {
+		return false, false, 0
+	}
+	min := values[0]
+	for _, value := range values {
+		min = math.Min(min, value)
+	}
+	lowerBoundTest := min <= scaleDownValue
+	upperBoundTest := min >= scaleUpValue
+	return lowerBoundTest, upperBoundTest, min
+}
+
+// calculateEwma weights every sample by 0.5^(age/halfLife), where age is measured from the newest sample
+func calculateEwma(scrapeList []MetricValidateResult, scaleDownValue float64, scaleUpValue float64, halfLife time.Duration) (bool, bool, float64) {
+	flatScrapeList := flattenScrapeList(scrapeList)
+	var samples []*model2.Scalar
+	for _, scrape := range flatScrapeList {
+		if sample, ok := scrape.Value[0].(*model2.Scalar); ok {
+			samples = append(samples, sample)
+		}
+	}
+	if len(samples) <= 0 || halfLife <= 0 {
+		return false, false, 0
+	}
+	newest := samples[0].Timestamp
+	for _, sample := range samples {
+		if sample.Timestamp.After(newest) {
+			newest = sample.Timestamp
+		}
+	}
+	var weightedSum, weightSum = 0.0, 0.0
+	for _, sample := range samples {
+		age := newest.Sub(sample.Timestamp)
+		weight := math.Pow(0.5, float64(age)/float64(halfLife))
+		weightedSum += weight * float64(sample.Value)
+		weightSum += weight
+	}
+	ewma := weightedSum / weightSum
+	lowerBoundTest := ewma <= scaleDownValue
+	upperBoundTest := ewma >= scaleUpValue
+	return lowerBoundTest, upperBoundTest, ewma
+}
+
+func flattenScrapeValues(scrapeList []MetricValidateResult) []float64 {
+	var result []float64
+	for _, scrape := range flattenScrapeList(scrapeList) {
+		if value, ok := scrape.Value[0].(*model2.Scalar); ok {
+			result = append(result, float64(value.Value))
+		}
+	}
+	return result
+}
+
 func calculateMean(scrapeList []MetricValidateResult, scaleDownValue float64, scaleUpValue float64) (bool, bool, float64) {
 	if scrapeList == nil || len(scrapeList) <= 0 {
 		return false, false, 0
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/util"
+	model2 "github.com/prometheus/common/model"
+	"math"
+	"testing"
+	"time"
+)
+
+func scalarScrapes(values ...float64) []MetricValidateResult {
+	var scrapes []MetricValidateResult
+	for _, value := range values {
+		scrapes = append(scrapes, MetricValidateResult{IsMetricValid: true, Value: []model2.Value{&model2.Scalar{Value: model2.SampleValue(value)}}})
+	}
+	return scrapes
+}
+
+func TestCalculateRobustMean(t *testing.T) {
+	tests := []struct {
+		name              string
+		scrapes           []MetricValidateResult
+		trimmedPercentage int
+		expectedValue     float64
+		expectedLower     bool
+		expectedUpper     bool
+	}{
+		{
+			name:              "no scrapes",
+			trimmedPercentage: 20,
+		},
+		{
+			name:              "nothing trimmed",
+			scrapes:           scalarScrapes(1, 2, 6),
+			trimmedPercentage: 10,
+			expectedValue:     3,
+			expectedLower:     true,
+		},
+		{
+			name:              "one value trimmed from each side",
+			scrapes:           scalarScrapes(7, 1, 100, 3, 4, 5, 6, 2, 8, 9),
+			trimmedPercentage: 20,
+			expectedValue:     5.5,
+			expectedUpper:     true,
+		},
+		{
+			name:              "two values trimmed from each side",
+			scrapes:           scalarScrapes(-50, 4, 4, 4, -1, 4, 4, 50, 4, 70),
+			trimmedPercentage: 40,
+			expectedValue:     4,
+			expectedUpper:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lower, upper, value := calculateRobustMean(test.scrapes, 3, 4, test.trimmedPercentage)
+			if math.Abs(value-test.expectedValue) > 1e-9 || lower != test.expectedLower || upper != test.expectedUpper {
+				t.Errorf("calculateRobustMean() = %t, %t, %f, expected %t, %t, %f", lower, upper, value, test.expectedLower, test.expectedUpper, test.expectedValue)
+			}
+		})
+	}
+}
+
+func TestCalculateMedian(t *testing.T) {
+	multiValueScrape := scalarScrapes(1, 8, 4)
+	multiValueScrape[0].Value = append(multiValueScrape[0].Value, multiValueScrape[1].Value...)
+	tests := []struct {
+		name          string
+		scrapes       []MetricValidateResult
+		expectedValue float64
+		expectedLower bool
+		expectedUpper bool
+	}{
+		{
+			name: "no scrapes",
+		},
+		{
+			name:          "odd number of values",
+			scrapes:       scalarScrapes(5, 1, 3),
+			expectedValue: 3,
+			expectedLower: true,
+		},
+		{
+			name:          "even number of values",
+			scrapes:       scalarScrapes(4, 1, 3, 6),
+			expectedValue: 3.5,
+		},
+		{
+			// two scrapes with three values in total
+			name:          "values of flattened scrapes",
+			scrapes:       []MetricValidateResult{multiValueScrape[0], multiValueScrape[2]},
+			expectedValue: 4,
+			expectedUpper: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lower, upper, value := calculateMedian(test.scrapes, 3, 4)
+			if math.Abs(value-test.expectedValue) > 1e-9 || lower != test.expectedLower || upper != test.expectedUpper {
+				t.Errorf("calculateMedian() = %t, %t, %f, expected %t, %t, %f", lower, upper, value, test.expectedLower, test.expectedUpper, test.expectedValue)
+			}
+		})
+	}
+}
+
+func TestTestScrapesTotalValue(t *testing.T) {
+	metric := scalingv1.AutoscalingDefinitionMetric{Name: "load", Algorithm: "mean", ValueScope: "total", ScaleDownValue: "1", ScaleUpValue: "10"}
+	scrapes := scalarScrapes(4, 5)
+	scrapes[0].Replicas = 2
+	scrapes[1].Replicas = 4
+	testResult := TestScrapes(scrapes, metric)
+	if testResult.Value != 4.5 || testResult.Replicas != 4 {
+		t.Errorf("TestScrapes() value: %f, replicas: %d, expected 4.5 and 4", testResult.Value, testResult.Replicas)
+	}
+	if testResult.TotalValue != 14 {
+		t.Errorf("TestScrapes() total value: %f, expected mean of scrape totals 14", testResult.TotalValue)
+	}
+}
+
+func TestScrapeAndTestOnFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := util.NewFakeClock(start)
+	source := NewMemorySource()
+	RegisterMetricSource(MetricTypeMemory, source)
+	source.AddSample("pipeline_load", start, 90)
+	source.AddSample("pipeline_load", start.Add(2*time.Minute+5*time.Second), 10)
+	metric := scalingv1.AutoscalingDefinitionMetric{Name: "load", MetricType: MetricTypeMemory, PrometheusQuery: "pipeline_load",
+		ScaleDownValue: "20", ScaleUpValue: "80", ScaleValueType: "double", Algorithm: "mean", ScrapeInterval: "10s", TestInterval: "1m"}
+	scrape, err := MakeScrape(metric, TargetProviders{Clock: clock})
+	if err != nil {
+		t.Fatalf("MakeScrape() failed: %s", err.Error())
+	}
+	test, err := MakeTest(metric, scrape)
+	if err != nil {
+		t.Fatalf("MakeTest() failed: %s", err.Error())
+	}
+	defer func() {
+		test.ScrapeInterval <- true
+		test.TestInterval <- true
+	}()
+
+	var results []TestResult
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 3; i++ {
+			results = append(results, <-test.TestResultsChannel)
+		}
+		close(done)
+	}()
+	advanced := make(chan bool)
+	go func() {
+		clock.Advance(3 * time.Minute)
+		close(advanced)
+	}()
+	select {
+	case <-advanced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Advance() of fake clock blocked by scraper and tester")
+	}
+	<-done
+
+	expected := []struct {
+		value     float64
+		scaleUp   bool
+		scaleDown bool
+	}{
+		{value: 90, scaleUp: true},
+		{value: 90, scaleUp: true},
+		{value: 10, scaleDown: true},
+	}
+	for i, result := range results {
+		if !result.IsValid || result.ScrapeCount != 6 || result.Value != expected[i].value ||
+			result.UpperBoundTestPassed != expected[i].scaleUp || result.LowerBoundTestPassed != expected[i].scaleDown {
+			t.Errorf("test result %d = %+v, expected value %f from 6 scrapes", i, result, expected[i].value)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package metrics
 
 import (
-	"custom-hpa/model"
+	scalingv1 "custom-hpa/apis/scaling/v1"
 	"errors"
 	"fmt"
 	model2 "github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +18,9 @@ type MetricValidateResult struct {
 	MetricName       string
 	IsMetricValid    bool
 	Value            []model2.Value
+	ReadyPods        int
+	MissingPods      int
+	Replicas         int
 }
 
 type MetricValidateResultMap struct {
@@ -24,7 +29,7 @@ type MetricValidateResultMap struct {
 }
 
 // public functions
-func ValidateMetricBounds(value model2.Value, metric model.AutoscalingDefinitionMetric) (MetricValidateResult, error) {
+func ValidateMetricBounds(value model2.Value, metric scalingv1.AutoscalingDefinitionMetric, podStates map[string]PodState) (MetricValidateResult, error) {
 	var result = MetricValidateResult{}
 	var err error
 	switch value.Type() {
@@ -55,6 +60,10 @@ func ValidateMetricBounds(value model2.Value, metric model.AutoscalingDefinition
 		result.UpperBoundPassed = true
 		result.LowerBoundPassed = true
 		result.IsMetricValid = true
+		var isPerPod = false
+		if ok && podStates != nil {
+			res, isPerPod, result.ReadyPods, result.MissingPods = filterPodSamples(res, podStates)
+		}
 		if ok {
 			if res.Len() <= 0 {
 				err = errors.New("metrics vector is empty")
@@ -75,6 +84,9 @@ func ValidateMetricBounds(value model2.Value, metric model.AutoscalingDefinition
 					result.IsMetricValid = result.IsMetricValid && partialResult.IsMetricValid
 					result.Value = append(result.Value, &scalar)
 				}
+				if isPerPod {
+					validatePodBounds(&result, metric)
+				}
 			}
 		} else {
 			err = errors.New("cannot cast metric type to vector")
@@ -244,24 +256,138 @@ func (c *ValueScalar) validateTimeBounds(scaleDownValue string, scaleUpValue str
 	return result, nil
 }
 
-func validateRequiredMetricFields(metric model.AutoscalingDefinitionMetric) (err error) {
+// validatePodBounds replaces all-or-nothing bound tests of per pod vector with tests of pods mean,
+// where missing pods are treated conservatively
+func validatePodBounds(result *MetricValidateResult, metric scalingv1.AutoscalingDefinitionMetric) {
+	scaleDownValue, err := strconv.ParseFloat(metric.ScaleDownValue, 64)
+	if err != nil {
+		return
+	}
+	scaleUpValue, err := strconv.ParseFloat(metric.ScaleUpValue, 64)
+	if err != nil {
+		return
+	}
+	var sum = 0.0
+	for _, value := range result.Value {
+		if scalar, ok := value.(*model2.Scalar); ok {
+			sum += float64(scalar.Value)
+		}
+	}
+	mean := sum / float64(len(result.Value))
+	result.LowerBoundPassed, result.UpperBoundPassed = conservativeBoundTests(mean, result.ReadyPods, result.MissingPods, scaleDownValue, scaleUpValue)
+}
+
+func validateRequiredMetricFields(metric scalingv1.AutoscalingDefinitionMetric) (err error) {
 	if &metric == nil {
 		err = errors.New("metric cannot be null")
 		return
 	}
-	if len(metric.Name) <= 0 || len(metric.MetricType) <= 0 || len(metric.ScaleDownValue) <= 0 || len(metric.ScaleUpValue) <= 0 || len(metric.ScaleValueType) <= 0 {
-		err = errors.New("one of required fields not found: name, metricType, scaleDownValue, scaleUpValue, scaleValueType")
+	if allErrs := requiredMetricFieldErrors(metric, field.NewPath("metric")); len(allErrs) > 0 {
+		err = allErrs.ToAggregate()
+		return
+	}
+	if len(metric.Algorithm) > 0 && !IsAlgorithmSupported(metric.Algorithm) {
+		err = errors.New(fmt.Sprintf("algorithm %s of metric %s is not supported, supported algorithms: %s", metric.Algorithm, metric.Name, strings.Join(SupportedAlgorithms(), ", ")))
 		return
 	}
 	return nil
 }
 
-func fillEmptyMetricFields(metric *model.AutoscalingDefinitionMetric) {
+func requiredMetricFieldErrors(metric scalingv1.AutoscalingDefinitionMetric, fldPath *field.Path) field.ErrorList {
+	var required = map[string]string{
+		"name":       metric.Name,
+		"metricType": metric.MetricType,
+	}
+	if pipelineAlgorithm, ok := getPipelineAlgorithm(metric.Algorithm); ok {
+		for name, value := range pipelineAlgorithm.RequiredFields(metric) {
+			required[name] = value
+		}
+	} else {
+		required["scaleDownValue"] = metric.ScaleDownValue
+		required["scaleUpValue"] = metric.ScaleUpValue
+		required["scaleValueType"] = metric.ScaleValueType
+	}
+	var names []string
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	allErrs := field.ErrorList{}
+	for _, name := range names {
+		if len(required[name]) <= 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child(name), ""))
+		}
+	}
+	return allErrs
+}
+
+// FillEmptyMetricFields fills defaults of common fields and of fields of every algorithm, so that metric runs with any algorithm
+func FillEmptyMetricFields(metric *scalingv1.AutoscalingDefinitionMetric) {
+	fillCommonMetricFields(metric)
+	for _, fillAlgorithmFields := range algorithmFieldFillers {
+		fillAlgorithmFields(metric)
+	}
+}
+
+// FillRelevantMetricFields fills defaults of common fields and of fields used by algorithm of metric only. Defaulting webhook
+// persists them, so that stored metric holds no defaults of other algorithms.
+func FillRelevantMetricFields(metric *scalingv1.AutoscalingDefinitionMetric) {
+	fillCommonMetricFields(metric)
+	if fillAlgorithmFields, ok := algorithmFieldFillers[strings.ToLower(metric.Algorithm)]; ok {
+		fillAlgorithmFields(metric)
+	}
+}
+
+var algorithmFieldFillers = map[string]func(metric *scalingv1.AutoscalingDefinitionMetric){
+	"percentile": func(metric *scalingv1.AutoscalingDefinitionMetric) {
+		if metric.Percentile <= 0 || metric.Percentile > 100 {
+			metric.Percentile = 95
+		}
+	},
+	"ewma": func(metric *scalingv1.AutoscalingDefinitionMetric) {
+		if _, err := time.ParseDuration(metric.EwmaHalfLife); len(metric.EwmaHalfLife) <= 0 || err != nil {
+			metric.EwmaHalfLife = "30s"
+		}
+	},
+	AlgorithmQueue: func(metric *scalingv1.AutoscalingDefinitionMetric) {
+		if _, err := time.ParseDuration(metric.TargetDrainTime); len(metric.TargetDrainTime) <= 0 || err != nil {
+			metric.TargetDrainTime = "5m"
+		}
+	},
+	"arimax": fillPredictiveMetricFields,
+	"pid": func(metric *scalingv1.AutoscalingDefinitionMetric) {
+		if _, err := strconv.ParseFloat(metric.ProportionalGain, 64); err != nil {
+			metric.ProportionalGain = "0.0"
+		}
+		if _, err := strconv.ParseFloat(metric.IntegralGain, 64); err != nil {
+			metric.IntegralGain = "0.0"
+		}
+		if _, err := strconv.ParseFloat(metric.DerivativeGain, 64); err != nil {
+			metric.DerivativeGain = "0.0"
+		}
+		if metric.MaxReplicaDelta <= 0 {
+			metric.MaxReplicaDelta = 2
+		}
+	},
+	AlgorithmExternal: func(metric *scalingv1.AutoscalingDefinitionMetric) {
+		if len(metric.ExternalProtocol) <= 0 {
+			metric.ExternalProtocol = "http"
+		}
+		if _, err := time.ParseDuration(metric.ExternalTimeout); len(metric.ExternalTimeout) <= 0 || err != nil {
+			metric.ExternalTimeout = "5s"
+		}
+		if metric.ExternalHistoryLength <= 0 {
+			metric.ExternalHistoryLength = 10
+		}
+	},
+}
+
+func fillCommonMetricFields(metric *scalingv1.AutoscalingDefinitionMetric) {
 	if metric.NumOfTests <= 0 {
 		metric.NumOfTests = 1
 	}
 	if len(metric.Algorithm) <= 0 {
-		metric.Algorithm = "default"
+		metric.Algorithm = AlgorithmDefault
 	}
 	if metric.TrimmedPercentage < 0 {
 		metric.TrimmedPercentage = 0
@@ -281,41 +407,54 @@ func fillEmptyMetricFields(metric *model.AutoscalingDefinitionMetric) {
 	if _, err := time.ParseDuration(metric.TestInterval); len(metric.TestInterval) < 0 || err != nil {
 		metric.TestInterval = "1m"
 	}
+}
+
+func fillPredictiveMetricFields(metric *scalingv1.AutoscalingDefinitionMetric) {
 	if metric.AutoregresionDegree < 0 {
 		metric.AutoregresionDegree = 0
 	}
 	if metric.MovingAverageDegree < 0 {
 		metric.MovingAverageDegree = 0
 	}
-	if metric.AutoregressionCoefficients == nil {
-		metric.AutoregressionCoefficients = []string{}
+	if metric.DifferencingOrder < 0 {
+		metric.DifferencingOrder = 0
+	}
+	if metric.SeasonalPeriod < 0 {
+		metric.SeasonalPeriod = 0
+	}
+	if metric.SeasonalAutoregressionDegree < 0 {
+		metric.SeasonalAutoregressionDegree = 0
 	}
-	if metric.MovingAverageCoefficients == nil {
-		metric.MovingAverageCoefficients = []string{}
+	if metric.SeasonalMovingAverageDegree < 0 {
+		metric.SeasonalMovingAverageDegree = 0
+	}
+	if metric.SeasonalDifferencingOrder < 0 {
+		metric.SeasonalDifferencingOrder = 0
+	}
+	if _, err := strconv.ParseFloat(metric.Intercept, 64); err != nil {
+		metric.Intercept = "0.0"
 	}
 	if _, err := strconv.ParseFloat(metric.ExogenousRegressorCoefficient, 64); err != nil {
 		metric.ExogenousRegressorCoefficient = "0.0"
 	}
-	for i, coef := range metric.AutoregressionCoefficients {
-		if _, err := strconv.ParseFloat(coef, 64); err != nil {
-			metric.AutoregressionCoefficients[i] = "0.0"
-		}
+	metric.AutoregressionCoefficients = fillCoefficients(metric.AutoregressionCoefficients, metric.AutoregresionDegree)
+	metric.MovingAverageCoefficients = fillCoefficients(metric.MovingAverageCoefficients, metric.MovingAverageDegree)
+	metric.SeasonalAutoregressionCoefficients = fillCoefficients(metric.SeasonalAutoregressionCoefficients, metric.SeasonalAutoregressionDegree)
+	metric.SeasonalMovingAverageCoefficients = fillCoefficients(metric.SeasonalMovingAverageCoefficients, metric.SeasonalMovingAverageDegree)
+}
+
+// fillCoefficients replaces invalid coefficients by zero and appends zero coefficients up to degree
+func fillCoefficients(coefficients []string, degree int) []string {
+	if coefficients == nil {
+		coefficients = []string{}
 	}
-	for i, coef := range metric.MovingAverageCoefficients {
+	for i, coef := range coefficients {
 		if _, err := strconv.ParseFloat(coef, 64); err != nil {
-			metric.MovingAverageCoefficients[i] = "0.0"
+			coefficients[i] = "0.0"
 		}
 	}
-	if len(metric.MovingAverageCoefficients) < metric.MovingAverageDegree {
-		length := len(metric.MovingAverageCoefficients)
-		for i := 0; i < metric.MovingAverageDegree-length; i++ {
-			metric.MovingAverageCoefficients = append(metric.MovingAverageCoefficients, "0.0")
-		}
-	}
-	if len(metric.AutoregressionCoefficients) < metric.AutoregresionDegree {
-		length := len(metric.AutoregressionCoefficients)
-		for i := 0; i < metric.AutoregresionDegree-length; i++ {
-			metric.AutoregressionCoefficients = append(metric.AutoregressionCoefficients, "0.0")
-		}
+	for len(coefficients) < degree {
+		coefficients = append(coefficients, "0.0")
 	}
+	return coefficients
 }
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"reflect"
+	"testing"
+)
+
+func TestFillRelevantMetricFields(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		expected  scalingv1.AutoscalingDefinitionMetric
+	}{
+		{
+			algorithm: "",
+			expected:  scalingv1.AutoscalingDefinitionMetric{Algorithm: "default", NumOfTests: 1, ScrapeInterval: "1s", TestInterval: "1m"},
+		},
+		{
+			algorithm: "Percentile",
+			expected:  scalingv1.AutoscalingDefinitionMetric{Algorithm: "Percentile", NumOfTests: 1, ScrapeInterval: "1s", TestInterval: "1m", Percentile: 95},
+		},
+		{
+			algorithm: "pid",
+			expected: scalingv1.AutoscalingDefinitionMetric{Algorithm: "pid", NumOfTests: 1, ScrapeInterval: "1s", TestInterval: "1m",
+				ProportionalGain: "0.0", IntegralGain: "0.0", DerivativeGain: "0.0", MaxReplicaDelta: 2},
+		},
+		{
+			algorithm: "queue",
+			expected:  scalingv1.AutoscalingDefinitionMetric{Algorithm: "queue", NumOfTests: 1, ScrapeInterval: "1s", TestInterval: "1m", TargetDrainTime: "5m"},
+		},
+		{
+			algorithm: "external",
+			expected: scalingv1.AutoscalingDefinitionMetric{Algorithm: "external", NumOfTests: 1, ScrapeInterval: "1s", TestInterval: "1m",
+				ExternalProtocol: "http", ExternalTimeout: "5s", ExternalHistoryLength: 10},
+		},
+	}
+	for _, test := range tests {
+		metric := scalingv1.AutoscalingDefinitionMetric{Algorithm: test.algorithm}
+		FillRelevantMetricFields(&metric)
+		if !reflect.DeepEqual(metric, test.expected) {
+			t.Errorf("FillRelevantMetricFields() of %q algorithm = %+v, expected %+v", test.algorithm, metric, test.expected)
+		}
+	}
+
+	metric := scalingv1.AutoscalingDefinitionMetric{Algorithm: "arimax", AutoregresionDegree: 2}
+	FillRelevantMetricFields(&metric)
+	if len(metric.AutoregressionCoefficients) != 2 || metric.Intercept != "0.0" || len(metric.ExternalProtocol) > 0 || metric.MaxReplicaDelta != 0 {
+		t.Errorf("FillRelevantMetricFields() of arimax algorithm = %+v, expected predictive defaults only", metric)
+	}
+}
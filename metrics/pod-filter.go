@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"custom-hpa/util"
+	model2 "github.com/prometheus/common/model"
+)
+
+type PodState struct {
+	IsIgnored bool
+}
+
+// PodStateProvider returns states of target pods keyed by pod name, nil provider disables pod awareness
+type PodStateProvider func() (map[string]PodState, error)
+
+// ReplicaCountProvider returns current number of target replicas used to normalize total metric values
+type ReplicaCountProvider func() (int, error)
+
+type TargetProviders struct {
+	PodStates    PodStateProvider
+	ReplicaCount ReplicaCountProvider
+	Clock        util.Clock
+}
+
+// filterPodSamples drops samples of ignored and unknown pods and counts pods without any sample.
+// Second result is false when samples are not labeled by pod and cannot be filtered.
+func filterPodSamples(vector model2.Vector, podStates map[string]PodState) (filtered model2.Vector, isPerPod bool, readyPods int, missingPods int) {
+	seen := make(map[string]bool)
+	for _, sample := range vector {
+		pod, ok := samplePodName(sample)
+		if !ok {
+			return vector, false, 0, 0
+		}
+		state, known := podStates[pod]
+		if !known || state.IsIgnored {
+			continue
+		}
+		seen[pod] = true
+		filtered = append(filtered, sample)
+	}
+	for pod, state := range podStates {
+		if state.IsIgnored {
+			continue
+		}
+		if seen[pod] {
+			readyPods++
+		} else {
+			missingPods++
+		}
+	}
+	return filtered, true, readyPods, missingPods
+}
+
+func samplePodName(sample *model2.Sample) (string, bool) {
+	if pod, ok := sample.Metric["pod"]; ok {
+		return string(pod), true
+	}
+	if pod, ok := sample.Metric["pod_name"]; ok {
+		return string(pod), true
+	}
+	return "", false
+}
+
+// conservativeBoundTests treats missing pods like upstream HPA: as idle pods when testing upper bound
+// and as pods at scale up value when testing lower bound, so missing pods never amplify scaling.
+func conservativeBoundTests(value float64, readyPods int, missingPods int, scaleDownValue float64, scaleUpValue float64) (bool, bool) {
+	if missingPods <= 0 {
+		return value <= scaleDownValue, value >= scaleUpValue
+	}
+	if readyPods <= 0 {
+		return false, false
+	}
+	pods := float64(readyPods + missingPods)
+	upperValue := value * float64(readyPods) / pods
+	lowerValue := (value*float64(readyPods) + scaleUpValue*float64(missingPods)) / pods
+	return lowerValue <= scaleDownValue, upperValue >= scaleUpValue
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	promApi "github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	model2 "github.com/prometheus/common/model"
+)
+
+// PrometheusSource evaluates instant queries against Prometheus server at spec address
+type PrometheusSource struct{}
+
+func (PrometheusSource) Query(ctx context.Context, spec MetricSourceSpec) (model2.Value, error) {
+	if len(spec.Address) <= 0 || len(spec.Query) <= 0 {
+		return nil, errors.New("prometheus query or path should not be null")
+	}
+	client, err := promApi.NewClient(promApi.Config{Address: spec.Address})
+	if err != nil {
+		return nil, err
+	}
+	api := v1.NewAPI(client)
+	value, warnings, err := api.Query(ctx, spec.Query, spec.Time)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		fmt.Printf("Warnings: %v\n", warnings)
+	}
+	return value, nil
+}
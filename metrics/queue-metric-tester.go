@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/util"
+	"errors"
+	model2 "github.com/prometheus/common/model"
+	"log"
+	"time"
+)
+
+var ErrEmptyMetric = errors.New("metrics vector is empty")
+
+type QueueTestResultsChannel struct {
+	QueueTestResultsChannel chan QueueTestResult
+	TestInterval            chan bool
+}
+
+type QueueTestResult struct {
+	MetricName     string
+	Backlog        float64
+	ArrivalRate    float64
+	ProcessingRate float64
+	IsValid        bool
+}
+
+// public functions
+func MakeQueueTest(metric scalingv1.AutoscalingDefinitionMetric, clock util.Clock) (QueueTestResultsChannel, error) {
+	err := validateRequiredMetricFields(metric)
+	if err != nil {
+		return QueueTestResultsChannel{}, err
+	}
+	FillEmptyMetricFields(&metric)
+	testDuration, err := time.ParseDuration(metric.TestInterval)
+	if err != nil {
+		return QueueTestResultsChannel{}, err
+	}
+	queueTestResultsChannel, testInterval := testQueueMetric(metric, testDuration, util.ClockOrReal(clock))
+	return QueueTestResultsChannel{
+		QueueTestResultsChannel: queueTestResultsChannel,
+		TestInterval:            testInterval,
+	}, nil
+}
+
+// ReadScalarMetric reads metric as single value, samples of vector are summed, so that query may return value per pod
+func ReadScalarMetric(metricType string, spec MetricSourceSpec) (float64, error) {
+	value, err := ReadMetric(metricType, spec)
+	if err != nil {
+		return 0, err
+	}
+	return reduceMetricValue(value, false)
+}
+
+// ReadPerReplicaMetric reads metric of single replica, samples of vector returned per pod are averaged
+func ReadPerReplicaMetric(metricType string, spec MetricSourceSpec) (float64, error) {
+	value, err := ReadMetric(metricType, spec)
+	if err != nil {
+		return 0, err
+	}
+	return reduceMetricValue(value, true)
+}
+
+// private functions
+func queueRequiredFields(metric scalingv1.AutoscalingDefinitionMetric) map[string]string {
+	return map[string]string{
+		"backlogQuery":        metric.BacklogQuery,
+		"arrivalRateQuery":    metric.ArrivalRateQuery,
+		"processingRateQuery": metric.ProcessingRateQuery,
+	}
+}
+
+func testQueueMetric(metric scalingv1.AutoscalingDefinitionMetric, testDuration time.Duration, clock util.Clock) (queueTestResultsChannel chan QueueTestResult, testInterval chan bool) {
+	queueTestResultsChannel = make(chan QueueTestResult)
+	testInterval = clock.SetInterval(func() {
+		now := clock.Now()
+		backlog, err := ReadScalarMetric(metric.MetricType, MetricSourceSpec{Address: metric.PrometheusPath, Query: metric.BacklogQuery, Time: now})
+		if err != nil {
+			log.Printf("Backlog query error: %s", err.Error())
+			queueTestResultsChannel <- QueueTestResult{MetricName: metric.Name, IsValid: false}
+			return
+		}
+		arrivalRate, err := ReadScalarMetric(metric.MetricType, MetricSourceSpec{Address: metric.PrometheusPath, Query: metric.ArrivalRateQuery, Time: now})
+		if err != nil {
+			log.Printf("Arrival rate query error: %s", err.Error())
+			queueTestResultsChannel <- QueueTestResult{MetricName: metric.Name, IsValid: false}
+			return
+		}
+		processingRate, err := ReadPerReplicaMetric(metric.MetricType, MetricSourceSpec{Address: metric.PrometheusPath, Query: metric.ProcessingRateQuery, Time: now})
+		if err != nil {
+			log.Printf("Processing rate query error: %s", err.Error())
+			queueTestResultsChannel <- QueueTestResult{MetricName: metric.Name, IsValid: false}
+			return
+		}
+		queueTestResultsChannel <- QueueTestResult{
+			MetricName:     metric.Name,
+			Backlog:        backlog,
+			ArrivalRate:    arrivalRate,
+			ProcessingRate: processingRate,
+			IsValid:        true,
+		}
+	}, testDuration, false)
+	return
+}
+
+func reduceMetricValue(value model2.Value, average bool) (float64, error) {
+	switch value.Type() {
+	case model2.ValScalar:
+		res, ok := value.(*model2.Scalar)
+		if ok {
+			return float64(res.Value), nil
+		}
+		return 0, errors.New("cannot cast metric type to scalar")
+	case model2.ValVector:
+		res, ok := value.(model2.Vector)
+		if !ok {
+			return 0, errors.New("cannot cast metric type to vector")
+		}
+		if res.Len() <= 0 {
+			return 0, ErrEmptyMetric
+		}
+		var sum = 0.0
+		for _, sample := range res {
+			sum += float64(sample.Value)
+		}
+		if average {
+			return sum / float64(res.Len()), nil
+		}
+		return sum, nil
+	}
+	return 0, errors.New("cannot recognize metric type")
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	model2 "github.com/prometheus/common/model"
+	"testing"
+)
+
+func TestReduceMetricValue(t *testing.T) {
+	perPod := model2.Vector{{Value: 10}, {Value: 20}, {Value: 30}}
+	tests := []struct {
+		name          string
+		value         model2.Value
+		average       bool
+		expectedValue float64
+		expectedError bool
+	}{
+		{name: "scalar", value: &model2.Scalar{Value: 5}, expectedValue: 5},
+		{name: "scalar per replica", value: &model2.Scalar{Value: 5}, average: true, expectedValue: 5},
+		{name: "vector is summed", value: perPod, expectedValue: 60},
+		{name: "vector per replica is averaged", value: perPod, average: true, expectedValue: 20},
+		{name: "empty vector", value: model2.Vector{}, expectedError: true},
+		{name: "matrix", value: model2.Matrix{}, expectedError: true},
+	}
+	for _, test := range tests {
+		value, err := reduceMetricValue(test.value, test.average)
+		if test.expectedError {
+			if err == nil {
+				t.Errorf("%s: reduceMetricValue() = %f, expected error", test.name, value)
+			}
+			continue
+		}
+		if err != nil || value != test.expectedValue {
+			t.Errorf("%s: reduceMetricValue() = %f, %v, expected %f", test.name, value, err, test.expectedValue)
+		}
+	}
+}
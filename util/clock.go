@@ -0,0 +1,36 @@
+package util
+
+import (
+	"time"
+)
+
+// Clock is source of time and timers of metric pipelines and autoscaling process, so that they can run against fake clock.
+type Clock interface {
+	Now() time.Time
+	SetInterval(executableFunc func(), duration time.Duration, async bool) chan bool
+	SetTimeout(executableFunc func(), duration time.Duration)
+}
+
+type realClock struct{}
+
+var RealClock Clock = realClock{}
+
+// ClockOrReal returns real clock when clock is not set
+func ClockOrReal(clock Clock) Clock {
+	if clock == nil {
+		return RealClock
+	}
+	return clock
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) SetInterval(executableFunc func(), duration time.Duration, async bool) chan bool {
+	return SetInterval(executableFunc, duration, async)
+}
+
+func (realClock) SetTimeout(executableFunc func(), duration time.Duration) {
+	SetTimeout(executableFunc, duration)
+}
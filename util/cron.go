@@ -0,0 +1,113 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CronSchedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	anyDay      bool
+	anyWeekday  bool
+}
+
+// ParseCron parses standard five field cron expression: minute, hour, day of month, month, day of week.
+// Every field supports "*", lists, ranges and steps, i.e. "0 6-18/2 * * 1-5".
+func ParseCron(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errors.New(fmt.Sprintf("cron expression %q should have 5 fields", expression))
+	}
+	var schedule = CronSchedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1
+	}
+	return &schedule, nil
+}
+
+func (c *CronSchedule) Matches(t time.Time) bool {
+	if c.minutes&(1<<uint(t.Minute())) == 0 || c.hours&(1<<uint(t.Hour())) == 0 || c.months&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dayMatches := c.daysOfMonth&(1<<uint(t.Day())) != 0
+	weekdayMatches := c.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if c.anyDay || c.anyWeekday {
+		return dayMatches && weekdayMatches
+	}
+	return dayMatches || weekdayMatches
+}
+
+// IsActive reports whether the schedule fired within duration before now, looking back minute by minute.
+func (c *CronSchedule) IsActive(now time.Time, duration time.Duration) bool {
+	start := now.Add(-duration)
+	for t := now.Truncate(time.Minute); !t.Before(start); t = t.Add(-time.Minute) {
+		if c.Matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var result uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.New(fmt.Sprintf("invalid cron step %q", part))
+			}
+			part = part[:i]
+		}
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.New(fmt.Sprintf("invalid cron value %q", part))
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.New(fmt.Sprintf("invalid cron value %q", part))
+				}
+			} else if step > 1 {
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return 0, errors.New(fmt.Sprintf("cron value %q out of range %d-%d", part, min, max))
+		}
+		for v := from; v <= to; v += step {
+			result |= 1 << uint(v)
+		}
+	}
+	return result, nil
+}
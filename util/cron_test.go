@@ -0,0 +1,98 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expression    string
+		expectedError bool
+	}{
+		{expression: "0 6-18/2 * * 1-5"},
+		{expression: "*/15 * 1,15 * *"},
+		{expression: "0 0 * * 7"},
+		{expression: "0 0 * *", expectedError: true},
+		{expression: "60 * * * *", expectedError: true},
+		{expression: "0 18-6 * * *", expectedError: true},
+		{expression: "0 */0 * * *", expectedError: true},
+		{expression: "a * * * *", expectedError: true},
+		{expression: "0 0 0 * *", expectedError: true},
+		{expression: "0 0 * 13 *", expectedError: true},
+	}
+	for _, test := range tests {
+		_, err := ParseCron(test.expression)
+		if test.expectedError != (err != nil) {
+			t.Errorf("ParseCron(%q) error: %v, expected error: %t", test.expression, err, test.expectedError)
+		}
+	}
+}
+
+func TestCronScheduleMatches(t *testing.T) {
+	// 2020-01-06 is Monday
+	monday := time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		at         time.Time
+		expected   bool
+	}{
+		{name: "range start", expression: "0 6-18 * * *", at: monday.Add(6 * time.Hour), expected: true},
+		{name: "range end", expression: "0 6-18 * * *", at: monday.Add(18 * time.Hour), expected: true},
+		{name: "out of range", expression: "0 6-18 * * *", at: monday.Add(19 * time.Hour), expected: false},
+		{name: "range step", expression: "0 6-18/4 * * *", at: monday.Add(10 * time.Hour), expected: true},
+		{name: "range step skips hour", expression: "0 6-18/4 * * *", at: monday.Add(12 * time.Hour), expected: false},
+		{name: "wildcard step", expression: "*/15 * * * *", at: monday.Add(45 * time.Minute), expected: true},
+		{name: "wildcard step skips minute", expression: "*/15 * * * *", at: monday.Add(50 * time.Minute), expected: false},
+		{name: "value step runs to maximum", expression: "5/20 * * * *", at: monday.Add(45 * time.Minute), expected: true},
+		{name: "list", expression: "0 8,20 * * *", at: monday.Add(20 * time.Hour), expected: true},
+		{name: "weekday range", expression: "0 0 * * 1-5", at: monday, expected: true},
+		{name: "weekend", expression: "0 0 * * 1-5", at: monday.AddDate(0, 0, 5), expected: false},
+		{name: "sunday as 7", expression: "0 0 * * 7", at: monday.AddDate(0, 0, 6), expected: true},
+		{name: "sunday as 0", expression: "0 0 * * 0", at: monday.AddDate(0, 0, 6), expected: true},
+		{name: "day of month or day of week", expression: "0 0 15 * 1", at: monday.AddDate(0, 0, 9), expected: true},
+		{name: "day of month and any day of week", expression: "0 0 15 * *", at: monday, expected: false},
+		{name: "month", expression: "0 0 * 2 *", at: monday, expected: false},
+	}
+	for _, test := range tests {
+		schedule, err := ParseCron(test.expression)
+		if err != nil {
+			t.Fatalf("%s: ParseCron(%q) failed: %s", test.name, test.expression, err.Error())
+		}
+		if matches := schedule.Matches(test.at); matches != test.expected {
+			t.Errorf("%s: Matches(%s) = %t, expected %t", test.name, test.at, matches, test.expected)
+		}
+	}
+}
+
+func TestCronScheduleIsActive(t *testing.T) {
+	// 2020-01-06 is Monday
+	monday := time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		duration   time.Duration
+		now        time.Time
+		expected   bool
+	}{
+		{name: "at start", expression: "0 9 * * *", duration: time.Hour, now: monday.Add(9 * time.Hour), expected: true},
+		{name: "before start", expression: "0 9 * * *", duration: time.Hour, now: monday.Add(9*time.Hour - time.Second), expected: false},
+		{name: "within window", expression: "0 9 * * *", duration: time.Hour, now: monday.Add(9*time.Hour + 59*time.Minute), expected: true},
+		{name: "after window", expression: "0 9 * * *", duration: time.Hour, now: monday.Add(10*time.Hour + time.Minute), expected: false},
+		{name: "crossing midnight before midnight", expression: "0 22 * * *", duration: 4 * time.Hour, now: monday.Add(23 * time.Hour), expected: true},
+		{name: "crossing midnight after midnight", expression: "0 22 * * *", duration: 4 * time.Hour, now: monday.Add(25 * time.Hour), expected: true},
+		{name: "crossing midnight after window", expression: "0 22 * * *", duration: 4 * time.Hour, now: monday.Add(26*time.Hour + time.Minute), expected: false},
+		{name: "friday night window on saturday", expression: "0 22 * * 5", duration: 8 * time.Hour, now: monday.AddDate(0, 0, 5).Add(3 * time.Hour), expected: true},
+		{name: "friday night window on sunday", expression: "0 22 * * 5", duration: 8 * time.Hour, now: monday.AddDate(0, 0, 6).Add(3 * time.Hour), expected: false},
+	}
+	for _, test := range tests {
+		schedule, err := ParseCron(test.expression)
+		if err != nil {
+			t.Fatalf("%s: ParseCron(%q) failed: %s", test.name, test.expression, err.Error())
+		}
+		if active := schedule.IsActive(test.now, test.duration); active != test.expected {
+			t.Errorf("%s: IsActive(%s, %s) = %t, expected %t", test.name, test.now, test.duration, active, test.expected)
+		}
+	}
+}
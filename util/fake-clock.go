@@ -0,0 +1,122 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is manually advanced clock. Timers fire synchronously within Advance in order of their time and of their creation,
+// except of async intervals, which are started in new goroutine as by real clock. Synchronous callbacks must not wait for each other.
+type FakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	fireAt         time.Time
+	interval       time.Duration
+	executableFunc func()
+	async          bool
+	isStopped      bool
+	clearInterval  chan bool
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// SetInterval returns buffered clear channel, so that interval is stopped deterministically before the next Advance.
+func (c *FakeClock) SetInterval(executableFunc func(), duration time.Duration, async bool) chan bool {
+	timer := c.addTimer(executableFunc, duration, duration, async)
+	return timer.clearInterval
+}
+
+func (c *FakeClock) SetTimeout(executableFunc func(), duration time.Duration) {
+	c.addTimer(executableFunc, duration, 0, false)
+}
+
+// Advance moves clock by duration and fires all timers due until new time.
+func (c *FakeClock) Advance(duration time.Duration) {
+	c.mutex.Lock()
+	target := c.now.Add(duration)
+	c.mutex.Unlock()
+	for {
+		timer := c.nextTimer(target)
+		if timer == nil {
+			break
+		}
+		if timer.async {
+			go timer.executableFunc()
+		} else {
+			timer.executableFunc()
+		}
+	}
+	c.mutex.Lock()
+	c.now = target
+	c.mutex.Unlock()
+}
+
+// PendingTimers returns number of timers, which have not fired or stopped yet.
+func (c *FakeClock) PendingTimers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pending := 0
+	for _, timer := range c.timers {
+		if !timer.checkStopped() {
+			pending++
+		}
+	}
+	return pending
+}
+
+func (c *FakeClock) addTimer(executableFunc func(), duration time.Duration, interval time.Duration, async bool) *fakeTimer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	timer := &fakeTimer{fireAt: c.now.Add(duration), interval: interval, executableFunc: executableFunc, async: async, clearInterval: make(chan bool, 1)}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// nextTimer moves clock to the earliest timer due until target and reschedules or removes it.
+func (c *FakeClock) nextTimer(target time.Time) *fakeTimer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var next *fakeTimer
+	var active []*fakeTimer
+	for _, timer := range c.timers {
+		if timer.checkStopped() {
+			continue
+		}
+		active = append(active, timer)
+		if !timer.fireAt.After(target) && (next == nil || timer.fireAt.Before(next.fireAt)) {
+			next = timer
+		}
+	}
+	c.timers = active
+	if next == nil {
+		return nil
+	}
+	c.now = next.fireAt
+	if next.interval > 0 {
+		next.fireAt = next.fireAt.Add(next.interval)
+	} else {
+		next.isStopped = true
+	}
+	return next
+}
+
+func (t *fakeTimer) checkStopped() bool {
+	select {
+	case <-t.clearInterval:
+		t.isStopped = true
+	default:
+	}
+	return t.isStopped
+}
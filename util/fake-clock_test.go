@@ -0,0 +1,70 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFakeClockFiresTimersInOrder(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	var fired []string
+	var firedAt []time.Duration
+	record := func(name string) func() {
+		return func() {
+			fired = append(fired, name)
+			firedAt = append(firedAt, clock.Now().Sub(start))
+		}
+	}
+	clock.SetInterval(record("interval"), 10*time.Second, false)
+	clock.SetTimeout(record("timeout"), 15*time.Second)
+
+	clock.Advance(30 * time.Second)
+
+	expectedFired := []string{"interval", "timeout", "interval", "interval"}
+	expectedFiredAt := []time.Duration{10 * time.Second, 15 * time.Second, 20 * time.Second, 30 * time.Second}
+	if !reflect.DeepEqual(fired, expectedFired) {
+		t.Errorf("fired %v, expected %v", fired, expectedFired)
+	}
+	if !reflect.DeepEqual(firedAt, expectedFiredAt) {
+		t.Errorf("fired at %v, expected %v", firedAt, expectedFiredAt)
+	}
+	if now := clock.Now().Sub(start); now != 30*time.Second {
+		t.Errorf("clock advanced by %s, expected 30s", now)
+	}
+	if pending := clock.PendingTimers(); pending != 1 {
+		t.Errorf("%d pending timers, expected 1", pending)
+	}
+}
+
+func TestFakeClockStopsInterval(t *testing.T) {
+	clock := NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	count := 0
+	clearInterval := clock.SetInterval(func() { count++ }, time.Minute, false)
+
+	clock.Advance(2 * time.Minute)
+	clearInterval <- true
+	clock.Advance(2 * time.Minute)
+
+	if count != 2 {
+		t.Errorf("interval fired %d times, expected 2", count)
+	}
+	if pending := clock.PendingTimers(); pending != 0 {
+		t.Errorf("%d pending timers, expected 0", pending)
+	}
+}
+
+func TestFakeClockTimeoutSetInCallback(t *testing.T) {
+	clock := NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	fired := false
+	clock.SetTimeout(func() {
+		clock.SetTimeout(func() { fired = true }, time.Second)
+	}, time.Second)
+
+	clock.Advance(2 * time.Second)
+
+	if !fired {
+		t.Error("timeout set by callback did not fire within the same Advance")
+	}
+}
@@ -0,0 +1,162 @@
+package validation
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/metrics"
+	"custom-hpa/util"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var supportedTargetTypes = []string{"deployment", "replicaset"}
+var supportedModes = []string{"auto", "recommend"}
+var supportedFallbackBehaviors = []string{"hold", "safeReplicas", "scaleUp"}
+var supportedRolloutPolicies = []string{"ignore", "defer", "cap"}
+
+// ValidateAutoscalingDefinition returns all semantic errors of definition with paths of invalid fields
+func ValidateAutoscalingDefinition(definition *scalingv1.AutoscalingDefinition) field.ErrorList {
+	return ValidateAutoscalingDefinitionSpec(definition.Spec, field.NewPath("spec"))
+}
+
+func ValidateAutoscalingDefinitionSpec(spec scalingv1.AutoscalingDefinitionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	targetPath := fldPath.Child("scaleTarget")
+	if len(spec.ScaleTarget.LabelName) <= 0 {
+		allErrs = append(allErrs, field.Required(targetPath.Child("labelName"), ""))
+	}
+	if len(spec.ScaleTarget.MatchLabel) <= 0 {
+		allErrs = append(allErrs, field.Required(targetPath.Child("matchLabel"), ""))
+	}
+	if len(spec.ScaleTarget.TargetType) > 0 && !metrics.ContainsFold(supportedTargetTypes, spec.ScaleTarget.TargetType) {
+		allErrs = append(allErrs, field.NotSupported(targetPath.Child("targetType"), spec.ScaleTarget.TargetType, supportedTargetTypes))
+	}
+
+	allErrs = append(allErrs, validateReplicas(spec.MinReplicas, spec.MaxReplicas, fldPath.Child("minReplicas"), fldPath.Child("maxReplicas"))...)
+	if spec.ScalingStep < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("scalingStep"), spec.ScalingStep, "must be greater than or equal to 0"))
+	}
+	if spec.DecisionHistoryLimit < 0 || spec.DecisionHistoryLimit > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("decisionHistoryLimit"), spec.DecisionHistoryLimit, "must be between 0 and 100"))
+	}
+	if len(spec.Mode) > 0 && !metrics.ContainsFold(supportedModes, spec.Mode) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), spec.Mode, supportedModes))
+	}
+	_, errs := metrics.ValidateDuration(spec.IntervalBetweenAutoscaling, "", fldPath.Child("intervalBetweenAutoscaling"))
+	allErrs = append(allErrs, errs...)
+	_, errs = metrics.ValidateDuration(spec.InitializationPeriod, "", fldPath.Child("initializationPeriod"))
+	allErrs = append(allErrs, errs...)
+	_, errs = metrics.ValidateDuration(spec.ReadinessDelay, "", fldPath.Child("readinessDelay"))
+	allErrs = append(allErrs, errs...)
+
+	metricsPath := fldPath.Child("metrics")
+	if len(spec.Metrics) <= 0 {
+		allErrs = append(allErrs, field.Required(metricsPath, "at least one metric is required"))
+	}
+	metricNames := make(map[string]bool)
+	for i, metric := range spec.Metrics {
+		allErrs = append(allErrs, metrics.ValidateMetric(metric, metricsPath.Index(i))...)
+		if metricNames[metric.Name] {
+			allErrs = append(allErrs, field.Duplicate(metricsPath.Index(i).Child("name"), metric.Name))
+		}
+		metricNames[metric.Name] = true
+	}
+
+	for i, schedule := range spec.Schedules {
+		allErrs = append(allErrs, validateSchedule(schedule, fldPath.Child("schedules").Index(i))...)
+	}
+	allErrs = append(allErrs, validateActivation(spec.ActivationMetric, fldPath.Child("activationMetric"))...)
+	allErrs = append(allErrs, validateFallback(spec.Fallback, fldPath.Child("fallback"))...)
+	allErrs = append(allErrs, validateRollout(spec.Rollout, fldPath.Child("rollout"))...)
+	return allErrs
+}
+
+func validateReplicas(minReplicas int, maxReplicas int, minPath *field.Path, maxPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if minReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(minPath, minReplicas, "must be greater than or equal to 0"))
+	}
+	if maxReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(maxPath, maxReplicas, "must be greater than or equal to 0"))
+	}
+	if maxReplicas > 0 && minReplicas > maxReplicas {
+		allErrs = append(allErrs, field.Invalid(minPath, minReplicas, "must be less than or equal to maxReplicas"))
+	}
+	return allErrs
+}
+
+func validateSchedule(schedule scalingv1.AutoscalingDefinitionSchedule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(schedule.Name) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	if len(schedule.Cron) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cron"), ""))
+	} else if _, err := util.ParseCron(schedule.Cron); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cron"), schedule.Cron, err.Error()))
+	}
+	if len(schedule.Duration) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("duration"), ""))
+	}
+	_, errs := metrics.ValidateDuration(schedule.Duration, "", fldPath.Child("duration"))
+	allErrs = append(allErrs, errs...)
+	if len(schedule.Timezone) > 0 {
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timezone"), schedule.Timezone, err.Error()))
+		}
+	}
+	allErrs = append(allErrs, validateReplicas(schedule.MinReplicas, schedule.MaxReplicas, fldPath.Child("minReplicas"), fldPath.Child("maxReplicas"))...)
+	if schedule.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), schedule.Replicas, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateActivation(activation scalingv1.AutoscalingDefinitionActivation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(activation.PrometheusQuery) > 0 && len(activation.PrometheusPath) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("prometheusPath"), "required when prometheusQuery is set"))
+	}
+	if len(activation.ActivationThreshold) > 0 {
+		if _, err := strconv.ParseFloat(activation.ActivationThreshold, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("activationThreshold"), activation.ActivationThreshold, "must be a number"))
+		}
+	}
+	if activation.ActivationReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("activationReplicas"), activation.ActivationReplicas, "must be greater than or equal to 0"))
+	}
+	_, errs := metrics.ValidateDuration(activation.IdlePeriod, "", fldPath.Child("idlePeriod"))
+	allErrs = append(allErrs, errs...)
+	_, errs = metrics.ValidateDuration(activation.ScrapeInterval, "", fldPath.Child("scrapeInterval"))
+	allErrs = append(allErrs, errs...)
+	return allErrs
+}
+
+func validateFallback(fallback scalingv1.AutoscalingDefinitionFallback, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(fallback.Behavior) > 0 && !metrics.ContainsFold(supportedFallbackBehaviors, fallback.Behavior) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("behavior"), fallback.Behavior, supportedFallbackBehaviors))
+	}
+	if fallback.FailureThreshold < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failureThreshold"), fallback.FailureThreshold, "must be greater than or equal to 0"))
+	}
+	if fallback.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), fallback.Replicas, "must be greater than or equal to 0"))
+	}
+	if strings.EqualFold(fallback.Behavior, "safeReplicas") && fallback.Replicas <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("replicas"), "required by safeReplicas behavior"))
+	}
+	return allErrs
+}
+
+func validateRollout(rollout scalingv1.AutoscalingDefinitionRollout, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(rollout.Policy) > 0 && !metrics.ContainsFold(supportedRolloutPolicies, rollout.Policy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("policy"), rollout.Policy, supportedRolloutPolicies))
+	}
+	_, errs := metrics.ValidateDuration(rollout.PostRolloutDelay, "", fldPath.Child("postRolloutDelay"))
+	allErrs = append(allErrs, errs...)
+	return allErrs
+}
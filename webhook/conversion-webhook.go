@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	scalingv2 "custom-hpa/apis/scaling/v2"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+)
+
+// conversionHandler converts autoscaling definitions between scaling.com/v1 and scaling.com/v2
+func conversionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := apiextensionsv1beta1.ConversionReview{}
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+			http.Error(w, "cannot decode conversion review", http.StatusBadRequest)
+			return
+		}
+		response := &apiextensionsv1beta1.ConversionResponse{
+			UID:    review.Request.UID,
+			Result: metav1.Status{Status: metav1.StatusSuccess},
+		}
+		for _, object := range review.Request.Objects {
+			converted, err := convertDefinition(object.Raw, review.Request.DesiredAPIVersion)
+			if err != nil {
+				response.ConvertedObjects = nil
+				response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+				break
+			}
+			response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+		}
+		review.Request = nil
+		review.Response = response
+		result, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("cannot encode conversion review: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(result)
+	})
+}
+
+func convertDefinition(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	switch {
+	case typeMeta.APIVersion == scalingv1.SchemeGroupVersion.String() && desiredAPIVersion == scalingv2.SchemeGroupVersion.String():
+		definition := scalingv1.AutoscalingDefinition{}
+		if err := json.Unmarshal(raw, &definition); err != nil {
+			return nil, err
+		}
+		return json.Marshal(scalingv2.ConvertFromV1(&definition))
+	case typeMeta.APIVersion == scalingv2.SchemeGroupVersion.String() && desiredAPIVersion == scalingv1.SchemeGroupVersion.String():
+		definition := scalingv2.AutoscalingDefinition{}
+		if err := json.Unmarshal(raw, &definition); err != nil {
+			return nil, err
+		}
+		return json.Marshal(scalingv2.ConvertToV1(&definition))
+	}
+	return nil, errors.New(fmt.Sprintf("unsupported conversion from %s to %s", typeMeta.APIVersion, desiredAPIVersion))
+}
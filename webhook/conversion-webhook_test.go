@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"bytes"
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	scalingv2 "custom-hpa/apis/scaling/v2"
+	"encoding/json"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const v1Definition = `{"apiVersion":"scaling.com/v1","kind":"AutoscalingDefinition","metadata":{"name":"example","namespace":"default"},` +
+	`"spec":{"scaleTarget":{"labelName":"app","matchLabel":"example"},"minReplicas":1,"maxReplicas":10,"intervalBetweenAutoscaling":"soon",` +
+	`"metrics":[{"name":"cpu","prometheusQuery":"avg(cpu)","scaleDownValue":"20","scaleUpValue":"80","scaleValueType":"double",` +
+	`"algorithm":"pid","targetValue":"70","proportionalGain":"0.5","integralGain":"0.1","derivativeGain":"0","maxReplicaDelta":2}]}}`
+
+func TestConvertDefinitionRoundTrip(t *testing.T) {
+	converted, err := convertDefinition([]byte(v1Definition), scalingv2.SchemeGroupVersion.String())
+	if err != nil {
+		t.Fatalf("convertDefinition() to v2 failed: %s", err.Error())
+	}
+	definitionV2 := scalingv2.AutoscalingDefinition{}
+	if err := json.Unmarshal(converted, &definitionV2); err != nil {
+		t.Fatalf("convertDefinition() returned invalid v2 definition: %s", err.Error())
+	}
+	if definitionV2.APIVersion != scalingv2.SchemeGroupVersion.String() || definitionV2.Spec.Metrics[0].Pid == nil || definitionV2.Spec.Metrics[0].Pid.TargetValue != 70 {
+		t.Errorf("convertDefinition() to v2 = %s, expected pid config with target value 70", converted)
+	}
+
+	restored, err := convertDefinition(converted, scalingv1.SchemeGroupVersion.String())
+	if err != nil {
+		t.Fatalf("convertDefinition() to v1 failed: %s", err.Error())
+	}
+	expected := scalingv1.AutoscalingDefinition{}
+	if err := json.Unmarshal([]byte(v1Definition), &expected); err != nil {
+		t.Fatalf("cannot unmarshal v1 definition: %s", err.Error())
+	}
+	definitionV1 := scalingv1.AutoscalingDefinition{}
+	if err := json.Unmarshal(restored, &definitionV1); err != nil {
+		t.Fatalf("convertDefinition() returned invalid v1 definition: %s", err.Error())
+	}
+	expectedRaw, _ := json.Marshal(expected)
+	restoredRaw, _ := json.Marshal(definitionV1)
+	if string(expectedRaw) != string(restoredRaw) {
+		t.Errorf("convertDefinition() round trip = %s, expected %s", restoredRaw, expectedRaw)
+	}
+}
+
+func TestConvertDefinitionUnsupportedVersion(t *testing.T) {
+	if _, err := convertDefinition([]byte(v1Definition), "scaling.com/v3"); err == nil {
+		t.Errorf("convertDefinition() to scaling.com/v3 succeeded, expected error")
+	}
+}
+
+func TestConversionHandler(t *testing.T) {
+	tests := []struct {
+		desiredAPIVersion string
+		expectedStatus    string
+		expectedObjects   int
+	}{
+		{desiredAPIVersion: scalingv2.SchemeGroupVersion.String(), expectedStatus: metav1.StatusSuccess, expectedObjects: 1},
+		{desiredAPIVersion: scalingv1.SchemeGroupVersion.String(), expectedStatus: metav1.StatusSuccess, expectedObjects: 1},
+		{desiredAPIVersion: "scaling.com/v3", expectedStatus: metav1.StatusFailure, expectedObjects: 0},
+	}
+	for _, test := range tests {
+		review := apiextensionsv1beta1.ConversionReview{
+			Request: &apiextensionsv1beta1.ConversionRequest{
+				UID:               "uid",
+				DesiredAPIVersion: test.desiredAPIVersion,
+				Objects:           []runtime.RawExtension{{Raw: []byte(v1Definition)}},
+			},
+		}
+		body, err := json.Marshal(review)
+		if err != nil {
+			t.Fatalf("cannot marshal conversion review: %s", err.Error())
+		}
+		recorder := httptest.NewRecorder()
+		conversionHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+
+		result := apiextensionsv1beta1.ConversionReview{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil || result.Response == nil {
+			t.Fatalf("conversionHandler() to %s returned invalid review: %s", test.desiredAPIVersion, recorder.Body.String())
+		}
+		if result.Response.UID != "uid" || result.Response.Result.Status != test.expectedStatus || len(result.Response.ConvertedObjects) != test.expectedObjects {
+			t.Errorf("conversionHandler() to %s = %+v, expected status %s with %d objects",
+				test.desiredAPIVersion, result.Response, test.expectedStatus, test.expectedObjects)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/autoscaler"
+	"custom-hpa/validation"
+	"encoding/json"
+	"fmt"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"log"
+	"reflect"
+)
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// defaultDefinition persists effective defaults into stored object, so that it shows configuration which actually runs.
+// Metrics get defaults of fields used by their algorithm only.
+// Invalid objects are left untouched, otherwise defaults would hide mistakes reported by validating webhook.
+func defaultDefinition(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if request.Operation == admissionv1beta1.Delete {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	definition := scalingv1.AutoscalingDefinition{}
+	if err := json.Unmarshal(request.Object.Raw, &definition); err != nil {
+		return deniedResponse(&metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: fmt.Sprintf("cannot decode autoscaling definition: %s", err.Error()),
+			Reason:  metav1.StatusReasonBadRequest,
+			Code:    400,
+		})
+	}
+	if allErrs := validation.ValidateAutoscalingDefinition(&definition); len(allErrs) > 0 {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	defaulted := scalingv1.AutoscalingDefinition{}
+	if err := json.Unmarshal(request.Object.Raw, &defaulted); err != nil {
+		return deniedResponse(&metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: fmt.Sprintf("cannot decode autoscaling definition: %s", err.Error()),
+			Reason:  metav1.StatusReasonBadRequest,
+			Code:    400,
+		})
+	}
+	autoscaler.FillDefinitionStoredDefaultValues(&defaulted)
+	if reflect.DeepEqual(definition.Spec, defaulted.Spec) {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	spec, err := mergeDefaultedSpec(request.Object.Raw, definition.Spec, defaulted.Spec)
+	if err != nil {
+		log.Printf("Defaulting patch error: %s", err.Error())
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	patch, err := json.Marshal([]patchOperation{{Op: "replace", Path: "/spec", Value: spec}})
+	if err != nil {
+		log.Printf("Defaulting patch error: %s", err.Error())
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// mergeDefaultedSpec sets defaulted values into spec sent by user. Fields which were not defaulted stay as sent,
+// so that zero values of unset fields are not written into stored object.
+func mergeDefaultedSpec(raw []byte, original scalingv1.AutoscalingDefinitionSpec, defaulted scalingv1.AutoscalingDefinitionSpec) (interface{}, error) {
+	object := struct {
+		Spec interface{} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+	originalValue, err := toJsonValue(original)
+	if err != nil {
+		return nil, err
+	}
+	defaultedValue, err := toJsonValue(defaulted)
+	if err != nil {
+		return nil, err
+	}
+	return mergeDefaults(object.Spec, originalValue, defaultedValue), nil
+}
+
+func mergeDefaults(target interface{}, original interface{}, defaulted interface{}) interface{} {
+	switch defaultedValue := defaulted.(type) {
+	case map[string]interface{}:
+		targetMap, ok := target.(map[string]interface{})
+		if !ok {
+			targetMap = make(map[string]interface{})
+		}
+		originalMap, _ := original.(map[string]interface{})
+		for key, value := range defaultedValue {
+			if !reflect.DeepEqual(originalMap[key], value) {
+				targetMap[key] = mergeDefaults(targetMap[key], originalMap[key], value)
+			}
+		}
+		return targetMap
+	case []interface{}:
+		targetList, targetOk := target.([]interface{})
+		originalList, originalOk := original.([]interface{})
+		if !targetOk || !originalOk || len(targetList) != len(defaultedValue) || len(originalList) != len(defaultedValue) {
+			return defaulted
+		}
+		for i, value := range defaultedValue {
+			if !reflect.DeepEqual(originalList[i], value) {
+				targetList[i] = mergeDefaults(targetList[i], originalList[i], value)
+			}
+		}
+		return targetList
+	default:
+		return defaulted
+	}
+}
+
+func toJsonValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	scalingv1 "custom-hpa/apis/scaling/v1"
+	"custom-hpa/validation"
+	"encoding/json"
+	"fmt"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"log"
+)
+
+// validateDefinition rejects definitions with invalid fields, so that mistakes are reported at apply instead of at runtime
+func validateDefinition(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if request.Operation == admissionv1beta1.Delete {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	definition := scalingv1.AutoscalingDefinition{}
+	if err := json.Unmarshal(request.Object.Raw, &definition); err != nil {
+		return deniedResponse(&metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: fmt.Sprintf("cannot decode autoscaling definition: %s", err.Error()),
+			Reason:  metav1.StatusReasonBadRequest,
+			Code:    400,
+		})
+	}
+	allErrs := validation.ValidateAutoscalingDefinition(&definition)
+	if len(allErrs) <= 0 {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	log.Printf("Rejected autoscaling definition %s/%s: %s", request.Namespace, definition.Name, allErrs.ToAggregate().Error())
+	var causes []metav1.StatusCause
+	for _, err := range allErrs {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseType(err.Type),
+			Message: err.ErrorBody(),
+			Field:   err.Field,
+		})
+	}
+	return deniedResponse(&metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: fmt.Sprintf("AutoscalingDefinition %q is invalid: %s", definition.Name, allErrs.ToAggregate().Error()),
+		Reason:  metav1.StatusReasonInvalid,
+		Details: &metav1.StatusDetails{
+			Name:   definition.Name,
+			Kind:   "AutoscalingDefinition",
+			Causes: causes,
+		},
+		Code: 422,
+	})
+}
+
+func deniedResponse(status *metav1.Status) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  status,
+	}
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"log"
+	"net/http"
+)
+
+type admitFunc func(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse
+
+// ListenAndServeTLS starts admission webhook server in background, api server requires webhooks to be served over https
+func ListenAndServeTLS(address string, certFile string, keyFile string) {
+	mux := http.NewServeMux()
+	mux.Handle("/validate", admissionHandler(validateDefinition))
+	mux.Handle("/mutate", admissionHandler(defaultDefinition))
+	mux.Handle("/convert", conversionHandler())
+	go func() {
+		log.Printf("Starting admission webhook server on %s", address)
+		if err := http.ListenAndServeTLS(address, certFile, keyFile, mux); err != nil {
+			log.Printf("Admission webhook server error: %s", err.Error())
+		}
+	}()
+}
+
+func admissionHandler(admit admitFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "expected application/json content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("cannot read request: %s", err.Error()), http.StatusBadRequest)
+			return
+		}
+		review := admissionv1beta1.AdmissionReview{}
+		if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+			http.Error(w, "cannot decode admission review", http.StatusBadRequest)
+			return
+		}
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+		review.Response = response
+		review.Request = nil
+		result, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("cannot encode admission review: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(result)
+	})
+}